@@ -0,0 +1,72 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/definition"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/generator/openapi"
+)
+
+// ConvertCommand Represents the struct of the convert command, it converts the specification into an OpenAPI 3.0's document
+type ConvertCommand struct {
+	SpecFile string
+	// SpecFormat overrides the format detected from the specification's content, e.g. raml, blueprint
+	SpecFormat string
+	// Output is the document's file, the document is written to Stdout if it isn't given
+	Output string
+	// JSON writes the document as JSON, it's YAML unless the output's extension is .json
+	JSON bool
+	// Stdout receives the document when there's no output, os.Stdout by default
+	Stdout io.Writer
+	// Strict fails the conversion if the parser reports warnings or if constructs can't be represented
+	Strict bool
+	// Logger reports the parser's diagnostics and the constructs which can't be represented, if given
+	Logger logrus.FieldLogger
+}
+
+// Execute
+func (c *ConvertCommand) Execute() (err error) {
+	var def *definition.Api
+	if def, err = parseSpec(c.SpecFile, c.SpecFormat, c.Strict, c.Logger); err != nil {
+		return
+	}
+
+	doc, warnings := openapi.Convert(*def)
+	reportConversion(c.Logger, c.SpecFile, warnings)
+
+	if c.Strict && len(warnings) > 0 {
+		return fmt.Errorf("The specification %s has %d construct(s) which can't be represented by OpenAPI, strict mode is enabled", c.SpecFile, len(warnings))
+	}
+
+	w := c.Stdout
+	if w == nil {
+		w = os.Stdout
+	}
+
+	if c.Output != "" {
+		if err = os.MkdirAll(filepath.Dir(c.Output), 0777); err != nil {
+			return
+		}
+
+		var f *os.File
+		if f, err = os.Create(c.Output); err != nil {
+			return
+		}
+		defer f.Close()
+
+		w = f
+	}
+
+	if c.JSON || strings.EqualFold(filepath.Ext(c.Output), ".json") {
+		err = doc.WriteJSON(w)
+	} else {
+		err = doc.WriteYAML(w)
+	}
+
+	return
+}
@@ -0,0 +1,78 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/definition"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/generator"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/generator/config"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/generator/postman"
+)
+
+// ExportCommand Represents the struct of the export command, it writes the parsed specification in a format read by other tools
+type ExportCommand struct {
+	SpecFile string
+	// SpecFormat overrides the format detected from the specification's content, e.g. raml, blueprint, openapi
+	SpecFormat string
+	// Format is the export's format, json (default) or postman
+	Format string
+	// Output is the export's file, the export is written to Stdout if it isn't given.
+	// The Postman's environment is written next to the collection's file, it isn't written to Stdout
+	Output string
+	// Stdout receives the export when there's no output, os.Stdout by default
+	Stdout io.Writer
+	// Strict fails the export if the parser reports warnings
+	Strict bool
+	// Logger reports the parser's diagnostics, if given
+	Logger logrus.FieldLogger
+}
+
+// Execute
+func (c *ExportCommand) Execute() (err error) {
+	format := c.Format
+	if format == "" {
+		format = config.FormatJSON
+	}
+
+	if format != config.FormatJSON && format != config.FormatPostman {
+		return fmt.Errorf("The export's format %s isn't supported, the formats supported are %s and %s", format, config.FormatJSON, config.FormatPostman)
+	}
+
+	var def *definition.Api
+	if def, err = parseSpec(c.SpecFile, c.SpecFormat, c.Strict, c.Logger); err != nil {
+		return
+	}
+
+	if c.Output == "" {
+		w := c.Stdout
+		if w == nil {
+			w = os.Stdout
+		}
+
+		if format == config.FormatPostman {
+			if c.Logger != nil {
+				c.Logger.WithField("file", c.SpecFile).Warn("The Postman's environment isn't written to Stdout, it's only written next to the collection given by --output")
+			}
+
+			col, _ := postman.Convert(*def)
+			return col.WriteJSON(w)
+		}
+
+		return generator.WriteJSON(w, *def)
+	}
+
+	var gen generator.Generator
+	gen, err = generator.NewGenerator(config.NewConfig(false, "", filepath.Dir(c.Output), filepath.Base(c.Output), nil, format), *def)
+
+	if err != nil {
+		return
+	}
+
+	err = gen.Generate()
+
+	return
+}
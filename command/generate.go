@@ -1,49 +1,28 @@
 package command
 
 import (
-	"path/filepath"
-
-	"github.com/pkg/errors"
+	"github.com/Sirupsen/logrus"
 	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/definition"
 	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/generator"
 	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/generator/config"
-	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/parser"
-	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/parser/transformer"
-)
-
-const (
-	BLUEPRINT = ".apib"
-	RAML      = ".raml"
 )
 
 // GenerateCommand Represents the struct of the generate command
 type GenerateCommand struct {
 	SpecFile   string
 	ConfigFile string
+	// Format overrides the format detected from the specification's content, e.g. raml, blueprint, openapi
+	Format string
+	// Strict fails the generation if the parser reports warnings
+	Strict bool
+	// Logger reports the parser's diagnostics, if given
+	Logger logrus.FieldLogger
 }
 
 // Execute
 func (c *GenerateCommand) Execute() (err error) {
-	var (
-		p     parser.Parser
-		trans transformer.Transformer
-	)
-
-	format := filepath.Ext(c.SpecFile)
-	switch format {
-	case BLUEPRINT:
-		p = parser.NewBlueprintParser()
-		trans = transformer.NewBlueprintTransformer()
-	case RAML:
-		p = parser.NewRamlParser()
-		trans = transformer.NewRamlTransformer()
-	default:
-		err = errors.Wrapf(err, "The format found %s for the specification given is unsuported", format)
-		return
-	}
-
 	var def *definition.Api
-	if def, err = p.Parse(c.SpecFile, trans); err != nil {
+	if def, err = parseSpec(c.SpecFile, c.Format, c.Strict, c.Logger); err != nil {
 		return
 	}
 
@@ -53,13 +32,19 @@ func (c *GenerateCommand) Execute() (err error) {
 	}
 
 	var gen generator.Generator
-	gen, err = generator.NewHTMLGenerator(cfg, *def)
+	gen, err = generator.NewGenerator(cfg, *def)
 
 	if err != nil {
 		return
 	}
 
-	err = gen.Generate()
+	if err = gen.Generate(); err != nil {
+		return
+	}
+
+	if reporter, ok := gen.(generator.Reporter); ok {
+		reportConversion(c.Logger, c.SpecFile, reporter.Warnings())
+	}
 
 	return
 }
@@ -0,0 +1,71 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/definition"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/parser"
+)
+
+// parseSpec Parses the specification's file, its format is detected from the content unless given.
+// The parser's warnings are logged and, in strict mode, they fail the parsing
+func parseSpec(specFile string, formatName string, strict bool, logger logrus.FieldLogger) (def *definition.Api, err error) {
+	var format parser.Format
+
+	if formatName != "" {
+		format, err = parser.FormatByName(formatName)
+	} else {
+		format, err = parser.DetectFormat(specFile)
+	}
+
+	if err != nil {
+		return
+	}
+
+	p := format.NewParser()
+
+	def, err = p.Parse(specFile, format.NewTransformer())
+
+	if diagnoser, ok := p.(parser.Diagnoser); ok {
+		warnings := diagnoser.Diagnostics().Warnings()
+		report(logger, specFile, warnings)
+
+		if err == nil && strict && len(warnings) > 0 {
+			err = fmt.Errorf("The specification %s has %d warning(s), strict mode is enabled", specFile, len(warnings))
+		}
+	}
+
+	return
+}
+
+// report Logs the diagnostics with their location
+func report(logger logrus.FieldLogger, specFile string, diagnostics parser.Diagnostics) {
+	if logger == nil {
+		return
+	}
+
+	for _, d := range diagnostics {
+		entry := logger.WithField("file", specFile)
+		if d.Line > 0 {
+			entry = entry.WithField("line", d.Line).WithField("column", d.Column)
+		}
+
+		if d.Severity == parser.SeverityError {
+			entry.Error(d.Message)
+		} else {
+			entry.Warn(d.Message)
+		}
+	}
+}
+
+// reportConversion Logs the constructs of the specification which couldn't be represented by the output
+func reportConversion(logger logrus.FieldLogger, specFile string, warnings []string) {
+	if logger == nil {
+		return
+	}
+
+	for _, w := range warnings {
+		logger.WithField("file", specFile).Warn(w)
+	}
+}
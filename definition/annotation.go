@@ -0,0 +1,29 @@
+package definition
+
+// Annotation represents an annotation applied to the api or its elements. e.g. (deprecated)
+type Annotation struct {
+	Name string `json:"name"`
+	// DisplayName and Description are taken from the annotation's type, if declared
+	DisplayName string      `json:"displayName,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Value       interface{} `json:"value,omitempty"`
+}
+
+// Annotations groups the annotations applied to an element
+type Annotations []Annotation
+
+// Has Checks if the annotation is applied
+func (as Annotations) Has(name string) bool {
+	_, ok := as.Get(name)
+	return ok
+}
+
+// Get Returns the annotation applied by its name
+func (as Annotations) Get(name string) (a Annotation, ok bool) {
+	for _, annotation := range as {
+		if annotation.Name == name {
+			return annotation, true
+		}
+	}
+	return
+}
@@ -5,17 +5,21 @@ type MediaType string
 
 // Api Definition structure
 type Api struct {
-	Title             string
-	Version           string
-	BaseURI           string
-	BaseURIParameters []Parameter
-	Protocols         []Protocol
-	MediaTypes        []MediaType
-	CustomTypes       []CustomType
-	Traits            []Trait
-	SecuritySchemes   []SecurityScheme
-	SecuredBy         []Option
-	ResourceGroups    []ResourceGroup
+	Title             string           `json:"title"`
+	Version           string           `json:"version,omitempty"`
+	BaseURI           string           `json:"baseUri,omitempty"`
+	BaseURIParameters []Parameter      `json:"baseUriParameters,omitempty"`
+	Protocols         []Protocol       `json:"protocols,omitempty"`
+	MediaTypes        []MediaType      `json:"mediaTypes,omitempty"`
+	CustomTypes       []CustomType     `json:"customTypes,omitempty"`
+	Traits            []Trait          `json:"traits,omitempty"`
+	SecuritySchemes   []SecurityScheme `json:"securitySchemes,omitempty"`
+	SecuredBy         []Option         `json:"securedBy,omitempty"`
+	ResourceGroups    []ResourceGroup  `json:"resourceGroups,omitempty"`
+	Annotations       Annotations      `json:"annotations,omitempty"`
+	Documentation     []Section        `json:"documentation,omitempty"`
+	// Metadata holds the document's metadata by their lowercased keys. e.g. format, host
+	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
 // CustomTypeByName Returns a CustomType struct based on its name
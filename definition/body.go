@@ -2,9 +2,11 @@ package definition
 
 // Body
 type Body struct {
-	Description string
-	Type        string
-	CustomType  *CustomType
-	MediaType   MediaType
-	Example     string
+	Description string      `json:"description,omitempty"`
+	Type        string      `json:"type,omitempty"`
+	CustomType  *CustomType `json:"customType,omitempty"`
+	MediaType   MediaType   `json:"mediaType,omitempty"`
+	Example     string      `json:"example,omitempty"`
+	// NamedExamples are the examples declared for the body or its type, pretty-printed for the body's media type
+	NamedExamples []Example `json:"namedExamples,omitempty"`
 }
@@ -2,25 +2,61 @@ package definition
 
 import "strings"
 
+// TypeKind represents the kind of a custom type once its type expression is resolved
+type TypeKind string
+
+const (
+	ObjectKind TypeKind = "object"
+	ArrayKind  TypeKind = "array"
+	UnionKind  TypeKind = "union"
+	ScalarKind TypeKind = "scalar"
+)
+
 // CustomType represents custom types
 type CustomType struct {
-	Name        string
-	Description string
-	Type        interface{}
-	Default     interface{}
-	Enum        interface{}
-	Properties  []CustomTypeProperty
-	Examples    []interface{}
+	Name        string               `json:"name,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Type        interface{}          `json:"type,omitempty"`
+	Default     interface{}          `json:"default,omitempty"`
+	Enum        interface{}          `json:"enum,omitempty"`
+	Properties  []CustomTypeProperty `json:"properties,omitempty"`
+	Examples    []interface{}        `json:"examples,omitempty"`
+	// NamedExamples are the examples with their names and facets (display name, strict)
+	NamedExamples []Example   `json:"namedExamples,omitempty"`
+	Annotations   Annotations `json:"annotations,omitempty"`
+	// Kind, Parents, Items and Members describe the resolved type, the parents' properties are merged into Properties
+	Kind    TypeKind     `json:"kind,omitempty"`
+	Parents []string     `json:"parents,omitempty"`
+	Items   *CustomType  `json:"items,omitempty"`
+	Members []CustomType `json:"members,omitempty"`
 }
 
 // CustomTypeProperty Represents a property of a custom type
 type CustomTypeProperty struct {
-	Name        string
-	Type        string
-	Required    bool
-	Description string
-	Example     string
-	Properties  []CustomTypeProperty
+	Name        string               `json:"name"`
+	Type        string               `json:"type,omitempty"`
+	Required    bool                 `json:"required,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Example     string               `json:"example,omitempty"`
+	Properties  []CustomTypeProperty `json:"properties,omitempty"`
+	// Facets restricting the property's values
+	Enum                 []interface{} `json:"enum,omitempty"`
+	Default              interface{}   `json:"default,omitempty"`
+	Format               string        `json:"format,omitempty"`
+	Pattern              *string       `json:"pattern,omitempty"`
+	MinLength            *int          `json:"minLength,omitempty"`
+	MaxLength            *int          `json:"maxLength,omitempty"`
+	Min                  *float64      `json:"min,omitempty"`
+	Max                  *float64      `json:"max,omitempty"`
+	MultipleOf           *float64      `json:"multipleOf,omitempty"`
+	MinItems             *int          `json:"minItems,omitempty"`
+	MaxItems             *int          `json:"maxItems,omitempty"`
+	UniqueItems          bool          `json:"uniqueItems,omitempty"`
+	MinProperties        *int          `json:"minProperties,omitempty"`
+	MaxProperties        *int          `json:"maxProperties,omitempty"`
+	AdditionalProperties *bool         `json:"additionalProperties,omitempty"`
+	Discriminator        string        `json:"discriminator,omitempty"`
+	DiscriminatorValue   string        `json:"discriminatorValue,omitempty"`
 }
 
 // CleanCustomTypeName It responsible for removing expressions
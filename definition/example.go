@@ -0,0 +1,12 @@
+package definition
+
+// Example represents a named example of a body or a custom type
+type Example struct {
+	Name        string      `json:"name,omitempty"`
+	DisplayName string      `json:"displayName,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Strict      bool        `json:"strict,omitempty"`
+	Value       interface{} `json:"value,omitempty"`
+	// Content is the value pretty-printed for the media type, e.g. indented JSON
+	Content string `json:"content,omitempty"`
+}
@@ -2,7 +2,7 @@ package definition
 
 // Header
 type Header struct {
-	Name        string
-	Description string
-	Example     interface{}
+	Name        string      `json:"name"`
+	Description string      `json:"description,omitempty"`
+	Example     interface{} `json:"example,omitempty"`
 }
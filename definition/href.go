@@ -2,7 +2,7 @@ package definition
 
 // Href
 type Href struct {
-	FullPath   string
-	Path       string
-	Parameters []Parameter
+	FullPath   string      `json:"fullPath,omitempty"`
+	Path       string      `json:"path,omitempty"`
+	Parameters []Parameter `json:"parameters,omitempty"`
 }
@@ -2,6 +2,6 @@ package definition
 
 // Option
 type Option struct {
-	Name       string
-	Parameters map[string]interface{}
+	Name       string                 `json:"name"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
 }
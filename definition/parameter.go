@@ -2,14 +2,18 @@ package definition
 
 // Parameter
 type Parameter struct {
-	Name        string
-	Description string
-	Type        string
-	Required    bool
-	Pattern     *string
-	MinLength   *int
-	MaxLength   *int
-	Min         *float64
-	Max         *float64
-	Example     interface{}
+	Name        string        `json:"name"`
+	Description string        `json:"description,omitempty"`
+	Type        string        `json:"type,omitempty"`
+	Required    bool          `json:"required,omitempty"`
+	Pattern     *string       `json:"pattern,omitempty"`
+	MinLength   *int          `json:"minLength,omitempty"`
+	MaxLength   *int          `json:"maxLength,omitempty"`
+	Min         *float64      `json:"min,omitempty"`
+	Max         *float64      `json:"max,omitempty"`
+	Example     interface{}   `json:"example,omitempty"`
+	Enum        []interface{} `json:"enum,omitempty"`
+	Default     interface{}   `json:"default,omitempty"`
+	Format      string        `json:"format,omitempty"`
+	Annotations Annotations   `json:"annotations,omitempty"`
 }
@@ -2,8 +2,8 @@ package definition
 
 // Request
 type Request struct {
-	Title       string
-	Description string
-	Body        []Body
-	Headers     []Header
+	Title       string   `json:"title,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Body        []Body   `json:"body,omitempty"`
+	Headers     []Header `json:"headers,omitempty"`
 }
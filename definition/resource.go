@@ -2,29 +2,31 @@ package definition
 
 // ResourceAction represents an action for a resource. e.g. GET /examples or POST /examples
 type ResourceAction struct {
-	Title        string
-	Description  string
-	Method       string
-	Href         Href
-	Is           []Option
-	SecuredBy    []Option
-	Transactions []Transaction
+	Title        string        `json:"title,omitempty"`
+	Description  string        `json:"description,omitempty"`
+	Method       string        `json:"method"`
+	Href         Href          `json:"href,omitempty"`
+	Is           []Option      `json:"is,omitempty"`
+	SecuredBy    []Option      `json:"securedBy,omitempty"`
+	Transactions []Transaction `json:"transactions,omitempty"`
+	Annotations  Annotations   `json:"annotations,omitempty"`
 }
 
 // Resource represents a resource. e.g. /examples
 type Resource struct {
-	Title       string
-	Description string
-	Href        Href
-	Is          []Option
-	SecuredBy   []Option
-	Actions     []ResourceAction
-	Resources   []Resource
+	Title       string           `json:"title,omitempty"`
+	Description string           `json:"description,omitempty"`
+	Href        Href             `json:"href,omitempty"`
+	Is          []Option         `json:"is,omitempty"`
+	SecuredBy   []Option         `json:"securedBy,omitempty"`
+	Actions     []ResourceAction `json:"actions,omitempty"`
+	Resources   []Resource       `json:"resources,omitempty"`
+	Annotations Annotations      `json:"annotations,omitempty"`
 }
 
 // ResourceGroup groups logically bound resources
 type ResourceGroup struct {
-	Title       string
-	Description string
-	Resources   []Resource
+	Title       string     `json:"title,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Resources   []Resource `json:"resources,omitempty"`
 }
@@ -2,8 +2,8 @@ package definition
 
 // Response
 type Response struct {
-	StatusCode  int
-	Description string
-	Headers     []Header
-	Body        []Body
+	StatusCode  int      `json:"statusCode"`
+	Description string   `json:"description,omitempty"`
+	Headers     []Header `json:"headers,omitempty"`
+	Body        []Body   `json:"body,omitempty"`
 }
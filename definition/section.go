@@ -0,0 +1,7 @@
+package definition
+
+// Section represents a page of the API's documentation, e.g. an introduction or an authentication's guide
+type Section struct {
+	Title   string `json:"title"`
+	Content string `json:"content,omitempty"`
+}
@@ -2,15 +2,15 @@ package definition
 
 // SecurityScheme
 type SecurityScheme struct {
-	Name         string
-	Description  string
-	Type         string
-	Transactions []Transaction
-	Settings     []SecuritySchemeSetting
+	Name         string                  `json:"name"`
+	Description  string                  `json:"description,omitempty"`
+	Type         string                  `json:"type,omitempty"`
+	Transactions []Transaction           `json:"transactions,omitempty"`
+	Settings     []SecuritySchemeSetting `json:"settings,omitempty"`
 }
 
 // Setting
 type SecuritySchemeSetting struct {
-	Name string
-	Data interface{}
+	Name string      `json:"name"`
+	Data interface{} `json:"data,omitempty"`
 }
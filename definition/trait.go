@@ -2,10 +2,10 @@ package definition
 
 // Trait Optional definition that
 type Trait struct {
-	Name         string
-	Usage        string
-	Description  string
-	Protocols    []Protocol
-	Href         Href
-	Transactions []Transaction
+	Name         string        `json:"name"`
+	Usage        string        `json:"usage,omitempty"`
+	Description  string        `json:"description,omitempty"`
+	Protocols    []Protocol    `json:"protocols,omitempty"`
+	Href         Href          `json:"href,omitempty"`
+	Transactions []Transaction `json:"transactions,omitempty"`
 }
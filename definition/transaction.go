@@ -2,6 +2,6 @@ package definition
 
 // Transaction groups a pair request/response
 type Transaction struct {
-	Request  Request
-	Response Response
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
 }
@@ -0,0 +1,81 @@
+package definition
+
+import (
+	"regexp"
+	"strings"
+)
+
+// uriParameterRe Matches the variables of the URI's template. e.g. {region}
+var uriParameterRe = regexp.MustCompile(`\{([^}]+)\}`)
+
+// uriExpressionRe Matches the expressions of the URI's template with their operators. e.g. {id}, {?limit,offset}
+var uriExpressionRe = regexp.MustCompile(`\{([+#./;?&]?)([^}]*)\}`)
+
+// URI represents the parts of the api's base URI, which can be a template. e.g. https://{region}.example.com/v1
+type URI struct {
+	Protocol Protocol `json:"protocol,omitempty"`
+	Host     string   `json:"host,omitempty"`
+	Path     string   `json:"path,omitempty"`
+	// Parameters are the names of the template's variables
+	Parameters []string `json:"parameters,omitempty"`
+}
+
+// ParseURI Splits the URI into its protocol, host and path. The protocol is optional, but an unsupported one is an error
+func ParseURI(uri string) (u URI, err error) {
+	rest := strings.TrimSpace(uri)
+
+	if i := strings.Index(rest, "://"); i >= 0 {
+		if u.Protocol, err = NewProtocolFromURL(rest); err != nil {
+			return
+		}
+		rest = rest[i+len("://"):]
+	}
+
+	if i := strings.Index(rest, "/"); i >= 0 {
+		u.Host, u.Path = rest[:i], rest[i:]
+	} else {
+		u.Host = rest
+	}
+
+	for _, m := range uriParameterRe.FindAllStringSubmatch(uri, -1) {
+		u.Parameters = append(u.Parameters, m[1])
+	}
+
+	return
+}
+
+// SplitURITemplate Returns the path of the URI template without its query's expressions, its path's variables and its query's variables.
+// e.g. /notes/{id}{?fields} is the path /notes/{id}, with the variable id and the query's variable fields
+func SplitURITemplate(uri string) (path string, pathVars, queryVars []string) {
+	path = uriExpressionRe.ReplaceAllStringFunc(uri, func(expr string) string {
+		m := uriExpressionRe.FindStringSubmatch(expr)
+
+		var names []string
+		for _, name := range strings.Split(m[2], ",") {
+			// The modifiers are removed, e.g. {ids*} or {name:3}
+			name = strings.TrimSuffix(strings.SplitN(strings.TrimSpace(name), ":", 2)[0], "*")
+			if name != "" {
+				names = append(names, name)
+			}
+		}
+
+		if m[1] == "?" || m[1] == "&" {
+			queryVars = append(queryVars, names...)
+			return ""
+		}
+
+		var vars []string
+		for _, name := range names {
+			pathVars = append(pathVars, name)
+			vars = append(vars, "{"+name+"}")
+		}
+
+		return strings.Join(vars, ",")
+	})
+
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	return
+}
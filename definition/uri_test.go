@@ -0,0 +1,48 @@
+package definition
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseURI(t *testing.T) {
+	uri, err := ParseURI("https://{region}.example.com/v1")
+	if assert.Nil(t, err) {
+		assert.Exactly(t, URI{Protocol: "https", Host: "{region}.example.com", Path: "/v1", Parameters: []string{"region"}}, uri)
+	}
+
+	// The protocol is optional
+	uri, err = ParseURI("api.example.com")
+	if assert.Nil(t, err) {
+		assert.Exactly(t, URI{Host: "api.example.com"}, uri)
+	}
+
+	_, err = ParseURI("ftp://example.com")
+	assert.NotNil(t, err)
+}
+
+func TestSplitURITemplate(t *testing.T) {
+	t.Parallel()
+
+	checks := []struct {
+		uri       string
+		path      string
+		pathVars  []string
+		queryVars []string
+	}{
+		{"", "/", nil, nil},
+		{"/notes", "/notes", nil, nil},
+		{"/notes/{id}{?fields,limit}", "/notes/{id}", []string{"id"}, []string{"fields", "limit"}},
+		{"/files/{+path}{&page}", "/files/{path}", []string{"path"}, []string{"page"}},
+		{"/tags/{names*}", "/tags/{names}", []string{"names"}, nil},
+	}
+
+	for _, check := range checks {
+		path, pathVars, queryVars := SplitURITemplate(check.uri)
+
+		assert.Exactly(t, check.path, path, check.uri)
+		assert.Exactly(t, check.pathVars, pathVars, check.uri)
+		assert.Exactly(t, check.queryVars, queryVars, check.uri)
+	}
+}
@@ -2,6 +2,14 @@ package config
 
 import "path/filepath"
 
+const (
+	FormatHTML     = "html"
+	FormatMarkdown = "markdown"
+	FormatJSON     = "json"
+	FormatOpenAPI  = "openapi"
+	FormatPostman  = "postman"
+)
+
 // Config Represents the configuration used on the generation process
 type config struct {
 	combine        bool
@@ -9,17 +17,27 @@ type config struct {
 	dstDir         string
 	outputFilename string
 	templates      []TemplateConfig
+	format         string
 }
 
 // NewConfig Return an instance of configuration
-func NewConfig(combine bool, srcDir string, dstDir string, outputFilename string, tmpls []TemplateConfig) (cfg config) {
+func NewConfig(combine bool, srcDir string, dstDir string, outputFilename string, tmpls []TemplateConfig, format string) (cfg config) {
 	return config{
 		combine,
 		srcDir,
 		dstDir,
 		outputFilename,
 		tmpls,
+		format,
+	}
+}
+
+// Format Returns the output's format, html by default
+func (c config) Format() string {
+	if c.format == "" {
+		return FormatHTML
 	}
+	return c.format
 }
 
 // IsCombined Returns the value for the flag combined
@@ -7,6 +7,7 @@ type Config interface {
 	Dst() string
 	Output() string
 	Templates() []TemplateConfig
+	Format() string
 }
 
 // TemplateConfig
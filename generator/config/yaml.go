@@ -4,6 +4,7 @@ import (
 	"io/ioutil"
 
 	"path/filepath"
+	"strings"
 
 	"github.com/gigforks/yaml"
 	"github.com/pkg/errors"
@@ -19,6 +20,9 @@ type YAML struct {
 		SrcFilename string `yaml:"src"`
 		DstFilename string `yaml:"dst,omitempty"`
 	} `yaml:"templates"`
+	Format string `yaml:"format,omitempty"`
+	// Engine is an alias of the format
+	Engine string `yaml:"engine,omitempty"`
 }
 
 // FromYaml Returns configuration fetched from a yaml file
@@ -41,7 +45,12 @@ func FromYaml(filename string) (cfg Config, err error) {
 		return
 	}
 
-	cfg = NewConfig(y.Combine, y.SrcDir, y.DstDir, y.OutputFilename, y.templates())
+	format := y.Format
+	if format == "" {
+		format = y.Engine
+	}
+
+	cfg = NewConfig(y.Combine, y.SrcDir, y.DstDir, y.OutputFilename, y.templates(), strings.ToLower(format))
 
 	return
 }
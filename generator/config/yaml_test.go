@@ -26,6 +26,7 @@ func TestFromYaml(t *testing.T) {
 				[]TemplateConfig{
 					NewTemplateConfig("simple.tmpl", "simple.html"),
 				},
+				"",
 			),
 		},
 		{
@@ -44,6 +45,7 @@ func TestFromYaml(t *testing.T) {
 					NewTemplateConfig("protocols.tmpl", ""),
 					NewTemplateConfig("mediaTypes.tmpl", ""),
 				},
+				"",
 			),
 		},
 		{
@@ -57,6 +59,19 @@ func TestFromYaml(t *testing.T) {
 				[]TemplateConfig{
 					NewTemplateConfig("simple.tmpl", "simple.html"),
 				},
+				"",
+			),
+		},
+		{
+			"Markdown configuration file without templates",
+			"testdata/markdown.yaml",
+			NewConfig(
+				false,
+				abs,
+				filepath.Join(abs, "destination"),
+				"API.md",
+				nil,
+				FormatMarkdown,
 			),
 		},
 	}
@@ -1,6 +1,32 @@
 package generator
 
+import (
+	"github.com/pkg/errors"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/definition"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/generator/config"
+)
+
 // Generator Interface
 type Generator interface {
 	Generate() (err error)
 }
+
+// NewGenerator Returns the generator of the configuration's format
+func NewGenerator(cfg config.Config, data definition.Api) (gen Generator, err error) {
+	switch cfg.Format() {
+	case config.FormatHTML:
+		return NewHTMLGenerator(cfg, data)
+	case config.FormatMarkdown:
+		return NewMarkdownGenerator(cfg, data)
+	case config.FormatJSON:
+		return NewJSONGenerator(cfg, data)
+	case config.FormatOpenAPI:
+		return NewOpenAPIGenerator(cfg, data)
+	case config.FormatPostman:
+		return NewPostmanGenerator(cfg, data)
+	}
+
+	err = errors.Errorf("The format %s isn't supported, the formats supported are %s, %s, %s, %s and %s", cfg.Format(), config.FormatHTML, config.FormatMarkdown, config.FormatJSON, config.FormatOpenAPI, config.FormatPostman)
+
+	return
+}
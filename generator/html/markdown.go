@@ -0,0 +1,37 @@
+package html
+
+import (
+	"html/template"
+	"regexp"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/russross/blackfriday"
+)
+
+// markdownExtensions GitHub flavored markdown's extensions: tables, fenced code, autolinks, strikethrough and headings' anchors
+const markdownExtensions = blackfriday.EXTENSION_NO_INTRA_EMPHASIS |
+	blackfriday.EXTENSION_TABLES |
+	blackfriday.EXTENSION_FENCED_CODE |
+	blackfriday.EXTENSION_AUTOLINK |
+	blackfriday.EXTENSION_STRIKETHROUGH |
+	blackfriday.EXTENSION_SPACE_HEADERS |
+	blackfriday.EXTENSION_AUTO_HEADER_IDS
+
+// markdownPolicy The allowlist of the elements and attributes rendered, anything else given by the specification is stripped (e.g. scripts)
+var markdownPolicy = newMarkdownPolicy()
+
+// newMarkdownPolicy Creates the policy for user generated content, allowing the fenced code's language as well
+func newMarkdownPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w-]+$`)).OnElements("code")
+
+	return p
+}
+
+// Markdown Renders the markdown as sanitized HTML
+func Markdown(s string) template.HTML {
+	renderer := blackfriday.HtmlRenderer(0, "", "")
+	unsafe := blackfriday.Markdown([]byte(s), renderer, markdownExtensions)
+
+	return template.HTML(markdownPolicy.SanitizeBytes(unsafe))
+}
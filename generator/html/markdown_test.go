@@ -0,0 +1,27 @@
+package html
+
+import (
+	"html/template"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdown(t *testing.T) {
+	checks := []struct {
+		Markdown string
+		Expected template.HTML
+	}{
+		{"# Getting Started", "<h1 id=\"getting-started\">Getting Started</h1>\n"},
+		{"```json\n{}\n```", "<pre><code class=\"language-json\">{}\n</code></pre>\n"},
+		{"| a |\n|---|\n| b |", "<table>\n<thead>\n<tr>\n<th>a</th>\n</tr>\n</thead>\n\n<tbody>\n<tr>\n<td>b</td>\n</tr>\n</tbody>\n</table>\n"},
+		{"~~old~~ http://example.com", "<p><del>old</del> <a href=\"http://example.com\" rel=\"nofollow\">http://example.com</a></p>\n"},
+		// The specification can't inject scripts
+		{"Hi<script>alert(1)</script>", "<p>Hi</p>\n"},
+		{"[x](javascript:alert(1)) <img src=x onerror=alert(1)>", "<p>x <img src=\"x\"></p>\n"},
+	}
+
+	for _, check := range checks {
+		assert.Exactly(t, check.Expected, Markdown(check.Markdown), check.Markdown)
+	}
+}
@@ -50,9 +50,11 @@ func (t *Template) Execute() (err error) {
 // helpers Returns the helpers given to the templates
 func helpers(data definition.Api) template.FuncMap {
 	return template.FuncMap{
+		// It injects the raw HTML, unsanitized. Descriptions must be rendered by Markdown instead
 		"NoEscape": func(t string) template.HTML {
 			return template.HTML(t)
 		},
+		"Markdown": Markdown,
 		// It returns the class of the http status code
 		"StatusCodeClass": func(c int) string {
 			s := strconv.Itoa(c)
@@ -50,6 +50,7 @@ func TestGenerate_HTML_Integration(t *testing.T) {
 				[]config.TemplateConfig{
 					config.NewTemplateConfig("simple.tmpl", "index.html"),
 				},
+				"",
 			),
 			filepath.Join(outputDir, "simple/index.html"),
 			"testdata/html/index.html",
@@ -74,6 +75,7 @@ func TestGenerate_HTML_Integration(t *testing.T) {
 					config.NewTemplateConfig("protocols.tmpl", ""),
 					config.NewTemplateConfig("mediaTypes.tmpl", ""),
 				},
+				"",
 			),
 			filepath.Join(outputDir, "advanced/index.html"),
 			"testdata/html/advanced/index.html",
@@ -0,0 +1,72 @@
+package generator
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/definition"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/generator/config"
+)
+
+// JSONSchemaVersion The version of the JSON export's schema.
+// Its major version is increased when fields are renamed, removed or change their type, the minor one when fields are added
+const JSONSchemaVersion = "1.0"
+
+// JSONDefaultOutput The name of the JSON export, when the configuration doesn't give an output
+const JSONDefaultOutput = "api.json"
+
+// JSONDocument Represents the JSON export, the api's definition tagged with the schema's version it follows
+type JSONDocument struct {
+	SchemaVersion string         `json:"schemaVersion"`
+	Api           definition.Api `json:"api"`
+}
+
+// JSON Represents a JSON's generator, it exports the api's definition
+type JSON struct {
+	output string
+	data   definition.Api
+}
+
+// NewJSONGenerator Returns a JSON's generator, its output is named api.json unless given
+func NewJSONGenerator(cfg config.Config, data definition.Api) (gen Generator, err error) {
+	output := cfg.Output()
+	if filepath.Clean(output) == filepath.Clean(cfg.Dst()) {
+		output = filepath.Join(cfg.Dst(), JSONDefaultOutput)
+	}
+
+	gen = &JSON{output: output, data: data}
+
+	return
+}
+
+// Generate Writes the JSON export to the output's file
+func (gen *JSON) Generate() (err error) {
+	if err = os.MkdirAll(filepath.Dir(gen.output), 0777); err != nil {
+		return
+	}
+
+	var f *os.File
+	if f, err = os.Create(gen.output); err != nil {
+		return
+	}
+	defer f.Close()
+
+	err = WriteJSON(f, gen.data)
+
+	return
+}
+
+// WriteJSON Writes the api's definition as an indented JSON document.
+// The fields keep the definition's order and the maps' keys are sorted, so the same definition is always written the same way
+func WriteJSON(w io.Writer, data definition.Api) (err error) {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	// The descriptions and examples are written as they're given, e.g. <br> isn't escaped as <br>
+	encoder.SetEscapeHTML(false)
+
+	err = encoder.Encode(JSONDocument{SchemaVersion: JSONSchemaVersion, Api: data})
+
+	return
+}
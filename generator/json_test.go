@@ -0,0 +1,136 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/definition"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/generator/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func testJSONData() definition.Api {
+	return definition.Api{
+		Title:             "Notes API",
+		Version:           "1.0",
+		BaseURI:           "https://api.example.com/{version}",
+		BaseURIParameters: []definition.Parameter{{Name: "version", Type: "string", Required: true}},
+		Protocols:         []definition.Protocol{"HTTPS"},
+		MediaTypes:        []definition.MediaType{"application/json"},
+		Metadata:          map[string]string{"host": "https://api.example.com/{version}", "format": "1A"},
+		ResourceGroups: []definition.ResourceGroup{
+			{
+				Title: "Notes",
+				Resources: []definition.Resource{
+					{
+						Title: "Note",
+						Href: definition.Href{
+							Path:       "/notes/{id}",
+							FullPath:   "/notes/{id}",
+							Parameters: []definition.Parameter{{Name: "id", Type: "number", Required: true, Example: 42.0}},
+						},
+						Actions: []definition.ResourceAction{
+							{
+								Title:  "Retrieve a Note",
+								Method: "GET",
+								Transactions: []definition.Transaction{
+									{
+										Response: definition.Response{
+											StatusCode: 200,
+											Body: []definition.Body{
+												{MediaType: "application/json", Type: "Note", Example: "{\"id\": 42, \"body\": \"<b>Buy milk</b>\"}"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		CustomTypes: []definition.CustomType{
+			{
+				Name: "Note",
+				Type: "object",
+				Kind: definition.ObjectKind,
+				Properties: []definition.CustomTypeProperty{
+					{Name: "id", Type: "number", Required: true},
+					{Name: "body", Type: "string"},
+				},
+				Examples: []interface{}{map[string]interface{}{"id": 42.0, "body": "Buy milk"}},
+			},
+		},
+	}
+}
+
+func TestGenerate_JSON_Integration(t *testing.T) {
+	outputDir, err := ioutil.TempDir("", "")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	// Clean up
+	defer func() {
+		os.RemoveAll(outputDir)
+	}()
+
+	checks := []struct {
+		Config config.Config
+		Output string
+	}{
+		{
+			// The export is named api.json when the configuration doesn't give an output
+			config.NewConfig(false, "", filepath.Join(outputDir, "default"), "", nil, config.FormatJSON),
+			filepath.Join(outputDir, "default/api.json"),
+		},
+		{
+			config.NewConfig(false, "", filepath.Join(outputDir, "named"), "notes.json", nil, config.FormatJSON),
+			filepath.Join(outputDir, "named/notes.json"),
+		},
+	}
+
+	expected, err := testLoadFile("testdata/json/api.json")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	for _, check := range checks {
+		gen, err := NewGenerator(check.Config, testJSONData())
+		if !assert.Nil(t, err) {
+			continue
+		}
+
+		assert.IsType(t, &JSON{}, gen)
+		assert.Nil(t, gen.Generate())
+
+		output, err := testLoadFile(check.Output)
+		assert.Nil(t, err)
+
+		assert.Exactly(t, expected, output)
+	}
+}
+
+func TestWriteJSON_RoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	if !assert.Nil(t, WriteJSON(&buf, testJSONData())) {
+		return
+	}
+
+	var doc JSONDocument
+	if !assert.Nil(t, json.Unmarshal(buf.Bytes(), &doc)) {
+		return
+	}
+
+	assert.Exactly(t, JSONSchemaVersion, doc.SchemaVersion)
+	assert.Exactly(t, testJSONData(), doc.Api)
+
+	// The same definition is always written the same way
+	var again bytes.Buffer
+	WriteJSON(&again, doc.Api)
+	assert.Exactly(t, buf.String(), again.String())
+}
@@ -0,0 +1,108 @@
+package generator
+
+import (
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/definition"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/generator/config"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/generator/markdown"
+)
+
+// Markdown Represents a markdown's generator
+type Markdown struct {
+	templates []*markdown.Template
+}
+
+// NewMarkdownGenerator Returns a Markdown's generator, the built-in template is used if the configuration doesn't give any
+func NewMarkdownGenerator(cfg config.Config, data definition.Api) (gen Generator, err error) {
+	mdGen := new(Markdown)
+
+	switch {
+	case len(cfg.Templates()) == 0:
+		err = mdGen.populateWithDefaultTemplate(cfg, data)
+	case cfg.IsCombined():
+		err = mdGen.populateWithCombinedTemplates(cfg, data)
+	default:
+		err = mdGen.populateWithTemplates(cfg, data)
+	}
+
+	if err == nil {
+		gen = mdGen
+	}
+
+	return
+}
+
+// Generate Generates the output based on the templates given
+func (gen *Markdown) Generate() (err error) {
+	if gen.templates == nil {
+		err = errors.New("There is no templates to be processed by the Markdown's generator.")
+	}
+
+	for _, tmpl := range gen.templates {
+		if err = tmpl.Execute(); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// populateWithDefaultTemplate It's responsible to create the built-in template, its output is named api.md unless given
+func (gen *Markdown) populateWithDefaultTemplate(cfg config.Config, data definition.Api) (err error) {
+	var template *markdown.Template
+
+	output := cfg.Output()
+	if filepath.Clean(output) == filepath.Clean(cfg.Dst()) {
+		output = filepath.Join(cfg.Dst(), markdown.DefaultTemplateName)
+	}
+
+	if template, err = markdown.NewDefaultTemplate(data, output); err != nil {
+		return
+	}
+
+	gen.templates = append(gen.templates, template)
+
+	return
+}
+
+// populateWithCombinedTemplates It's responsible to collect all templates from the configuration and create one Markdown's template
+func (gen *Markdown) populateWithCombinedTemplates(cfg config.Config, data definition.Api) (err error) {
+	var (
+		filenames []string
+		template  *markdown.Template
+	)
+
+	for _, tc := range cfg.Templates() {
+		filenames = append(filenames, filepath.Join(cfg.Src(), tc.Src()))
+	}
+
+	// For combined, the first template's filename will be used as template's name
+	name := filepath.Base(filenames[0])
+
+	if template, err = markdown.NewTemplate(name, data, filenames, cfg.Output()); err != nil {
+		return
+	}
+
+	gen.templates = append(gen.templates, template)
+
+	return
+}
+
+// populateWithTemplates It's responsible to create one Markdown's template by each template defined on the configuration
+func (gen *Markdown) populateWithTemplates(cfg config.Config, data definition.Api) (err error) {
+	var template *markdown.Template
+
+	for _, tc := range cfg.Templates() {
+		filename := filepath.Join(cfg.Src(), tc.Src())
+		output := filepath.Join(cfg.Dst(), tc.Dst())
+
+		if template, err = markdown.NewTemplate(filepath.Base(filename), data, []string{filename}, output); err != nil {
+			return
+		}
+
+		gen.templates = append(gen.templates, template)
+	}
+
+	return
+}
@@ -0,0 +1,158 @@
+package markdown
+
+// DefaultTemplateName The name of the built-in template, used when the configuration doesn't give any
+const DefaultTemplateName = "api.md"
+
+// defaultTemplates The built-in templates, documenting the api's resources and types in one file
+const defaultTemplates = `# {{.Title}}
+{{- if or .Version .BaseURI}}
+{{with .Version}}
+- **Version:** {{.}}
+{{- end}}
+{{- with .BaseURI}}
+- **Base URI:** ` + "`{{.}}`" + `
+{{- end}}
+{{- end}}
+{{- if .ResourceGroups}}
+
+## Contents
+{{range .Documentation}}
+- [{{.Title}}]({{Anchor .Title}})
+{{- end}}
+- [Resources](#resources)
+{{- if .CustomTypes}}
+- [Types](#types)
+{{- end}}
+{{- end}}
+{{- range .Documentation}}
+
+## {{.Title}}
+
+{{.Content}}
+{{- end}}
+{{- if .ResourceGroups}}
+
+## Resources
+{{- range .ResourceGroups}}
+{{- if .Title}}
+
+### {{.Title}}
+{{- with .Description}}
+
+{{.}}
+{{- end}}
+{{- end}}
+{{- range .Resources}}{{template "resource" .}}{{end}}
+{{- end}}
+{{- end}}
+{{- if .CustomTypes}}
+
+## Types
+{{- range .CustomTypes}}{{template "custom_type" .}}{{end}}
+{{- end}}
+{{- define "resource"}}
+{{- if .Actions}}
+
+#### {{with .Title}}{{.}} {{end}}` + "`{{.Href.FullPath}}`" + `
+{{- with .Description}}
+
+{{.}}
+{{- end}}
+{{- template "parameters" .Href.Parameters}}
+{{- range .Actions}}{{template "action" .}}{{end}}
+{{- end}}
+{{- range .Resources}}{{template "resource" .}}{{end}}
+{{- end}}
+{{- define "action"}}
+
+##### {{.Method}}{{with .Title}} {{.}}{{end}}
+{{- with .Description}}
+
+{{.}}
+{{- end}}
+{{- template "parameters" .Href.Parameters}}
+{{- range $i, $transaction := .Transactions}}
+{{- if eq $i 0}}{{template "request" .Request}}{{end}}
+{{- template "response" .Response}}
+{{- end}}
+{{- end}}
+{{- define "request"}}
+{{- if or .Description .Headers .Body}}
+
+**Request**{{with .Title}} {{.}}{{end}}
+{{- with .Description}}
+
+{{.}}
+{{- end}}
+{{- template "headers" .Headers}}
+{{- range .Body}}{{template "body" .}}{{end}}
+{{- end}}
+{{- end}}
+{{- define "response"}}
+
+**Response {{.StatusCode}}**
+{{- with .Description}}
+
+{{.}}
+{{- end}}
+{{- template "headers" .Headers}}
+{{- range .Body}}{{template "body" .}}{{end}}
+{{- end}}
+{{- define "parameters"}}
+{{- if .}}
+
+| Parameter | Type | Required | Description |
+| --- | --- | --- | --- |
+{{- range .}}
+| ` + "`{{.Name}}`" + ` | {{.Type}} | {{if .Required}}yes{{else}}no{{end}} | {{Cell .Description}} |
+{{- end}}
+{{- end}}
+{{- end}}
+{{- define "headers"}}
+{{- if .}}
+
+| Header | Example | Description |
+| --- | --- | --- |
+{{- range .}}
+| ` + "`{{.Name}}`" + ` | {{with .Example}}` + "`{{.}}`" + `{{end}} | {{Cell .Description}} |
+{{- end}}
+{{- end}}
+{{- end}}
+{{- define "body"}}
+{{- with .MediaType}}
+
+Body: ` + "`{{.}}`" + `
+{{- end}}
+{{- with .Type}} ([{{.}}]({{Anchor .}})){{end}}
+{{- with .CustomType}}{{template "properties" .Properties}}{{end}}
+{{- if .Example}}
+
+` + "```" + `{{Fence .MediaType}}
+{{TrimSuffix .Example "\n"}}
+` + "```" + `
+{{- end}}
+{{- end}}
+{{- define "custom_type"}}
+
+### {{.Name}}
+{{- with .Description}}
+
+{{.}}
+{{- end}}
+{{- with .Parents}}
+
+Inherits from {{range $i, $parent := .}}{{if $i}}, {{end}}[{{$parent}}]({{Anchor $parent}}){{end}}.
+{{- end}}
+{{- template "properties" .Properties}}
+{{- end}}
+{{- define "properties"}}
+{{- if .}}
+
+| Property | Type | Required | Description |
+| --- | --- | --- | --- |
+{{- range .}}
+| ` + "`{{.Name}}`" + ` | {{.Type}} | {{if .Required}}yes{{else}}no{{end}} | {{Cell .Description}} |
+{{- end}}
+{{- end}}
+{{- end}}
+`
@@ -0,0 +1,125 @@
+package markdown
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/definition"
+)
+
+// anchorRe Matches the characters removed from the headings' anchors, as GitHub does
+var anchorRe = regexp.MustCompile(`[^\p{L}\p{N}\s_-]`)
+
+// Template Represents markdown's template handler
+type Template struct {
+	handler *template.Template
+	data    definition.Api
+	name    string
+	output  string
+}
+
+// NewTemplate Returns the template parsed from the files given, the name is the template executed
+func NewTemplate(name string, data definition.Api, filenames []string, output string) (tmpl *Template, err error) {
+	handler := template.New(name)
+
+	handler.Funcs(helpers(data))
+
+	if handler, err = handler.ParseFiles(filenames...); err == nil {
+		tmpl = &Template{handler, data, name, output}
+	}
+	return
+}
+
+// NewDefaultTemplate Returns the built-in template, documenting the whole api in one file
+func NewDefaultTemplate(data definition.Api, output string) (tmpl *Template, err error) {
+	handler := template.New(DefaultTemplateName)
+
+	handler.Funcs(helpers(data))
+
+	if handler, err = handler.Parse(defaultTemplates); err == nil {
+		tmpl = &Template{handler, data, DefaultTemplateName, output}
+	}
+	return
+}
+
+// Execute Parses the templates and creates the output.
+func (t *Template) Execute() (err error) {
+	if err = createDir(t.output); err != nil {
+		return
+	}
+
+	var f *os.File
+	if f, err = os.Create(t.output); err != nil {
+		return
+	}
+	defer f.Close()
+
+	err = t.handler.ExecuteTemplate(f, t.name, t.data)
+
+	return
+}
+
+// helpers Returns the helpers given to the templates, the html's ones and the ones to write markdown
+func helpers(data definition.Api) template.FuncMap {
+	return template.FuncMap{
+		// It returns the class of the http status code
+		"StatusCodeClass": func(c int) string {
+			s := strconv.Itoa(c)
+			return s[0:1]
+		},
+		"Lower": strings.ToLower,
+		"Add": func(a int, b int) int {
+			return a + b
+		},
+		"TrimSuffix": func(s string, cutset string) string {
+			return strings.TrimSuffix(s, cutset)
+		},
+		"CustomTypeByName": func(name string) definition.CustomType {
+			return data.CustomTypeByName(definition.CleanCustomTypeName(name))
+		},
+		"Cell":   Cell,
+		"Anchor": Anchor,
+		"Fence":  Fence,
+	}
+}
+
+// Cell Escapes the text to be written in a table's cell, which holds one line
+func Cell(s string) string {
+	s = strings.Replace(strings.TrimSpace(s), "|", `\|`, -1)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// Anchor Returns the anchor of the heading, e.g. #get-notes for ## GET /notes
+func Anchor(heading string) string {
+	s := anchorRe.ReplaceAllString(strings.ToLower(strings.TrimSpace(heading)), "")
+	return "#" + strings.Replace(s, " ", "-", -1)
+}
+
+// Fence Returns the fenced code's language of the media type, e.g. json for application/hal+json
+func Fence(mediaType definition.MediaType) string {
+	s := strings.ToLower(string(mediaType))
+
+	switch {
+	case strings.Contains(s, "json"):
+		return "json"
+	case strings.Contains(s, "xml"):
+		return "xml"
+	case strings.Contains(s, "yaml"):
+		return "yaml"
+	}
+
+	return ""
+}
+
+// createDir Creates a directory if not exist
+func createDir(filename string) (err error) {
+	dir := filepath.Dir(filename)
+	if _, err = os.Stat(dir); os.IsNotExist(err) {
+		return os.MkdirAll(dir, 0777)
+	}
+	return
+}
@@ -0,0 +1,128 @@
+package generator
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/definition"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/generator/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate_Markdown_Integration(t *testing.T) {
+	outputDir, err := ioutil.TempDir("", "")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	// Clean up
+	defer func() {
+		os.RemoveAll(outputDir)
+	}()
+
+	data := definition.Api{
+		Title:   "Notes API",
+		Version: "1.0",
+		BaseURI: "https://api.example.com",
+		Documentation: []definition.Section{
+			{Title: "Getting Started", Content: "Authenticate with a token."},
+		},
+		ResourceGroups: []definition.ResourceGroup{
+			{
+				Title:       "Notes",
+				Description: "The notes | tasks.",
+				Resources: []definition.Resource{
+					{
+						Title: "Note",
+						Href: definition.Href{
+							FullPath:   "/notes/{id}",
+							Parameters: []definition.Parameter{{Name: "id", Type: "number", Required: true, Description: "The note's\nid"}},
+						},
+						Actions: []definition.ResourceAction{
+							{
+								Title:  "Retrieve a Note",
+								Method: "GET",
+								Transactions: []definition.Transaction{
+									{
+										Request: definition.Request{
+											Headers: []definition.Header{{Name: "Accept", Example: "application/json"}},
+										},
+										Response: definition.Response{
+											StatusCode: 200,
+											Body: []definition.Body{
+												{MediaType: "application/json", Type: "Note", Example: "{\"id\": 42}\n"},
+											},
+										},
+									},
+									{
+										Response: definition.Response{StatusCode: 404, Description: "The note doesn't exist."},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		CustomTypes: []definition.CustomType{
+			{
+				Name:       "Note",
+				Parents:    []string{"Base"},
+				Properties: []definition.CustomTypeProperty{{Name: "id", Type: "number", Required: true, Description: "The id"}},
+			},
+		},
+	}
+
+	checks := []struct {
+		Config         config.Config
+		Output         string
+		ExpectedOutput string
+	}{
+		{
+			// The built-in template is used when none is given
+			config.NewConfig(false, "", filepath.Join(outputDir, "default"), "", nil, config.FormatMarkdown),
+			filepath.Join(outputDir, "default/api.md"),
+			"testdata/markdown/api.md",
+		},
+		{
+			config.NewConfig(
+				false,
+				"testdata/markdown",
+				filepath.Join(outputDir, "simple"),
+				"",
+				[]config.TemplateConfig{
+					config.NewTemplateConfig("simple.tmpl", "README.md"),
+				},
+				config.FormatMarkdown,
+			),
+			filepath.Join(outputDir, "simple/README.md"),
+			"testdata/markdown/README.md",
+		},
+	}
+
+	for _, check := range checks {
+		gen, err := NewGenerator(check.Config, data)
+		if !assert.Nil(t, err) {
+			continue
+		}
+
+		assert.IsType(t, &Markdown{}, gen)
+		assert.Nil(t, gen.Generate())
+
+		expected, err := testLoadFile(check.ExpectedOutput)
+		assert.Nil(t, err)
+
+		output, err := testLoadFile(check.Output)
+		assert.Nil(t, err)
+
+		assert.Exactly(t, expected, output)
+	}
+}
+
+func TestNewGenerator_UnsupportedFormat(t *testing.T) {
+	_, err := NewGenerator(config.NewConfig(false, "", "", "", nil, "pdf"), definition.Api{})
+
+	assert.NotNil(t, err)
+}
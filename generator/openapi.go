@@ -0,0 +1,67 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/definition"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/generator/config"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/generator/openapi"
+)
+
+// OpenAPIDefaultOutput The name of the OpenAPI's document, when the configuration doesn't give an output
+const OpenAPIDefaultOutput = "openapi.yaml"
+
+// Reporter Implemented by the generators which report the constructs of the api's definition they couldn't represent
+type Reporter interface {
+	Warnings() []string
+}
+
+// OpenAPI Represents an OpenAPI's generator, it converts the api's definition into an OpenAPI 3.0's document
+type OpenAPI struct {
+	output   string
+	doc      *openapi.Document
+	warnings []string
+}
+
+// NewOpenAPIGenerator Returns an OpenAPI's generator, its output is named openapi.yaml unless given.
+// The document is written as JSON if the output's extension is .json, as YAML otherwise
+func NewOpenAPIGenerator(cfg config.Config, data definition.Api) (gen Generator, err error) {
+	output := cfg.Output()
+	if filepath.Clean(output) == filepath.Clean(cfg.Dst()) {
+		output = filepath.Join(cfg.Dst(), OpenAPIDefaultOutput)
+	}
+
+	doc, warnings := openapi.Convert(data)
+
+	gen = &OpenAPI{output: output, doc: doc, warnings: warnings}
+
+	return
+}
+
+// Generate Writes the OpenAPI's document to the output's file
+func (gen *OpenAPI) Generate() (err error) {
+	if err = os.MkdirAll(filepath.Dir(gen.output), 0777); err != nil {
+		return
+	}
+
+	var f *os.File
+	if f, err = os.Create(gen.output); err != nil {
+		return
+	}
+	defer f.Close()
+
+	if strings.EqualFold(filepath.Ext(gen.output), ".json") {
+		err = gen.doc.WriteJSON(f)
+	} else {
+		err = gen.doc.WriteYAML(f)
+	}
+
+	return
+}
+
+// Warnings Returns the constructs of the api's definition which couldn't be represented by the document
+func (gen *OpenAPI) Warnings() []string {
+	return gen.warnings
+}
@@ -0,0 +1,609 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/definition"
+)
+
+// ignoredHeaders The request's headers described by the OpenAPI's document itself, they can't be header parameters
+var ignoredHeaders = []string{"Accept", "Content-Type", "Authorization"}
+
+// converter Holds the state of the conversion, the warnings report the constructs which couldn't be represented
+type converter struct {
+	def      definition.Api
+	doc      *Document
+	warnings []string
+	// schemes tell if the security schemes, by their names, are converted
+	schemes map[string]bool
+	// annotations are the names of the annotations which aren't converted
+	annotations map[string]bool
+}
+
+// Convert Returns the OpenAPI's document of the api's definition and the warnings about the constructs which couldn't be represented
+func Convert(def definition.Api) (doc *Document, warnings []string) {
+	c := &converter{
+		def: def,
+		doc: &Document{
+			OpenAPI: Version,
+			Info:    Info{Title: def.Title, Version: def.Version, Description: description(def)},
+			Paths:   make(map[string]*PathItem),
+		},
+		schemes:     make(map[string]bool),
+		annotations: make(map[string]bool),
+	}
+
+	c.doc.Servers = c.servers()
+	c.components()
+	c.doc.Security = c.security(def.SecuredBy, "the api")
+	c.annotate(def.Annotations)
+
+	for _, g := range def.ResourceGroups {
+		var tags []string
+		if g.Title != "" {
+			tags = []string{g.Title}
+			c.tag(g)
+		}
+
+		for _, r := range g.Resources {
+			c.resource(r, nil, tags)
+		}
+	}
+
+	if len(def.Traits) > 0 {
+		var names []string
+		for _, t := range def.Traits {
+			names = append(names, t.Name)
+		}
+		c.warn("the traits (%s) are applied to the operations, OpenAPI has no equivalent of their declarations", strings.Join(names, ", "))
+	}
+
+	if len(c.annotations) > 0 {
+		var names []string
+		for name := range c.annotations {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		c.warn("the annotations (%s) aren't converted, OpenAPI has no equivalent of them", strings.Join(names, ", "))
+	}
+
+	return c.doc, c.warnings
+}
+
+// warn Reports a construct which couldn't be represented
+func (c *converter) warn(format string, args ...interface{}) {
+	c.warnings = append(c.warnings, fmt.Sprintf(format, args...))
+}
+
+// annotate Collects the annotations which aren't converted, deprecated is converted by the operations
+func (c *converter) annotate(annotations definition.Annotations) {
+	for _, a := range annotations {
+		if a.Name != "deprecated" {
+			c.annotations[a.Name] = true
+		}
+	}
+}
+
+// description Returns the api's description, its documentation's sections are written as markdown's headings
+func description(def definition.Api) string {
+	var sections []string
+	for _, s := range def.Documentation {
+		sections = append(sections, strings.TrimSpace(fmt.Sprintf("## %s\n\n%s", s.Title, s.Content)))
+	}
+
+	return strings.Join(sections, "\n\n")
+}
+
+// servers Returns the servers of the base uri, one by protocol declared. Its parameters are the server's variables
+func (c *converter) servers() (servers []Server) {
+	if c.def.BaseURI == "" {
+		return
+	}
+
+	uri, err := definition.ParseURI(c.def.BaseURI)
+	if err != nil {
+		c.warn("the base uri %s is invalid: %s", c.def.BaseURI, err)
+		return
+	}
+
+	variables := make(map[string]*ServerVariable)
+	for _, name := range uri.Parameters {
+		variables[name] = c.serverVariable(name)
+	}
+
+	if len(variables) == 0 {
+		variables = nil
+	}
+
+	// The base uri's protocol is replaced by the ones declared
+	address := c.def.BaseURI
+	if i := strings.Index(address, "://"); i >= 0 {
+		address = address[i+len("://"):]
+	}
+
+	protocols := c.def.Protocols
+	if len(protocols) == 0 {
+		if uri.Protocol == "" {
+			return []Server{{URL: c.def.BaseURI, Variables: variables}}
+		}
+		protocols = []definition.Protocol{uri.Protocol}
+	}
+
+	for _, p := range protocols {
+		servers = append(servers, Server{URL: strings.ToLower(string(p)) + "://" + address, Variables: variables})
+	}
+
+	return
+}
+
+// serverVariable Returns the variable of the base uri's parameter, its default is the api's version for RAML's {version}
+func (c *converter) serverVariable(name string) *ServerVariable {
+	v := &ServerVariable{}
+
+	var param definition.Parameter
+	for _, p := range c.def.BaseURIParameters {
+		if p.Name == name {
+			param = p
+		}
+	}
+
+	v.Description = param.Description
+	for _, e := range param.Enum {
+		v.Enum = append(v.Enum, fmt.Sprint(e))
+	}
+
+	switch {
+	case param.Default != nil:
+		v.Default = fmt.Sprint(param.Default)
+	case name == "version" && c.def.Version != "":
+		v.Default = c.def.Version
+	case len(v.Enum) > 0:
+		v.Default = v.Enum[0]
+	case param.Example != nil && fmt.Sprint(param.Example) != "":
+		v.Default = fmt.Sprint(param.Example)
+	default:
+		c.warn("the base uri's parameter %s has no default value, OpenAPI requires one", name)
+	}
+
+	return v
+}
+
+// tag Declares the resource group's tag, once by title
+func (c *converter) tag(g definition.ResourceGroup) {
+	for _, t := range c.doc.Tags {
+		if t.Name == g.Title {
+			return
+		}
+	}
+
+	c.doc.Tags = append(c.doc.Tags, Tag{Name: g.Title, Description: g.Description})
+}
+
+// resource Converts the resource's actions into the operations of its path, the parameters of the parent resources are inherited
+func (c *converter) resource(r definition.Resource, inherited []definition.Parameter, tags []string) {
+	params := mergeParameters(inherited, r.Href.Parameters)
+
+	path := r.Href.FullPath
+	if path == "" {
+		path = r.Href.Path
+	}
+
+	c.annotate(r.Annotations)
+
+	for _, a := range r.Actions {
+		actionPath := path
+		if a.Href.FullPath != "" {
+			actionPath = a.Href.FullPath
+		} else if a.Href.Path != "" {
+			actionPath = a.Href.Path
+		}
+
+		c.action(r, a, actionPath, params, tags)
+	}
+
+	for _, child := range r.Resources {
+		c.resource(child, params, tags)
+	}
+}
+
+// action Converts the action into an operation of its path, an operation is declared once by path and method
+func (c *converter) action(r definition.Resource, a definition.ResourceAction, uri string, params []definition.Parameter, tags []string) {
+	path, pathVars, queryVars := definition.SplitURITemplate(uri)
+	method := strings.ToLower(a.Method)
+	where := fmt.Sprintf("%s %s", strings.ToUpper(method), path)
+
+	item, ok := c.doc.Paths[path]
+	if !ok {
+		item = &PathItem{Summary: r.Title, Description: r.Description}
+		c.doc.Paths[path] = item
+	}
+
+	slot := item.operation(method)
+	if slot == nil {
+		c.warn("%s: the method isn't supported by OpenAPI", where)
+		return
+	}
+
+	if *slot != nil {
+		c.warn("%s: the operation is declared more than once, only the first one is kept", where)
+		return
+	}
+
+	c.annotate(a.Annotations)
+
+	op := &Operation{
+		Tags:        tags,
+		Summary:     a.Title,
+		Description: a.Description,
+		Deprecated:  a.Annotations.Has("deprecated"),
+		Responses:   make(map[string]*Response),
+	}
+
+	op.Parameters = c.parameters(mergeParameters(params, a.Href.Parameters), pathVars, queryVars, where)
+	c.transactions(op, a.Transactions, where)
+
+	securedBy := a.SecuredBy
+	if securedBy == nil {
+		securedBy = r.SecuredBy
+	}
+	op.Security = c.security(securedBy, where)
+
+	*slot = op
+}
+
+// operation Returns the item's operation of the method, nil if OpenAPI doesn't support the method
+func (item *PathItem) operation(method string) **Operation {
+	switch method {
+	case "get":
+		return &item.Get
+	case "put":
+		return &item.Put
+	case "post":
+		return &item.Post
+	case "delete":
+		return &item.Delete
+	case "options":
+		return &item.Options
+	case "head":
+		return &item.Head
+	case "patch":
+		return &item.Patch
+	case "trace":
+		return &item.Trace
+	}
+
+	return nil
+}
+
+// mergeParameters Returns the parameters inherited overridden by the ones given, by their names
+func mergeParameters(inherited, params []definition.Parameter) (merged []definition.Parameter) {
+	merged = append(merged, inherited...)
+
+	for _, p := range params {
+		replaced := false
+		for i := range merged {
+			if merged[i].Name == p.Name {
+				merged[i], replaced = p, true
+			}
+		}
+
+		if !replaced {
+			merged = append(merged, p)
+		}
+	}
+
+	return
+}
+
+// parameters Returns the path's parameters, in the path's order, then the query's parameters.
+// The path's variables which aren't described are strings, the parameters which aren't in the URI are query's parameters
+func (c *converter) parameters(params []definition.Parameter, pathVars, queryVars []string, where string) (parameters []*Parameter) {
+	byName := make(map[string]definition.Parameter, len(params))
+	for _, p := range params {
+		byName[p.Name] = p
+	}
+
+	for _, name := range pathVars {
+		p, ok := byName[name]
+		if !ok {
+			p = definition.Parameter{Name: name, Type: "string"}
+		}
+
+		param := c.parameter(p, "path", where)
+		// The path's parameters are always required
+		param.Required = true
+		parameters = append(parameters, param)
+	}
+
+	for _, p := range params {
+		if contains(pathVars, p.Name) {
+			continue
+		}
+
+		parameters = append(parameters, c.parameter(p, "query", where))
+	}
+
+	for _, name := range queryVars {
+		if _, ok := byName[name]; !ok {
+			parameters = append(parameters, c.parameter(definition.Parameter{Name: name, Type: "string"}, "query", where))
+		}
+	}
+
+	return
+}
+
+// parameter Converts the parameter located in the place given (path, query or header)
+func (c *converter) parameter(p definition.Parameter, in string, where string) *Parameter {
+	c.annotate(p.Annotations)
+
+	typ := p.Type
+	if typ == "" {
+		typ = "string"
+	}
+
+	schema := c.typeSchema(typ, where)
+	example := exampleValue(p.Example, schema.Type)
+
+	facets := Schema{
+		Pattern:   stringValue(p.Pattern),
+		MinLength: p.MinLength,
+		MaxLength: p.MaxLength,
+		Minimum:   p.Min,
+		Maximum:   p.Max,
+		Enum:      p.Enum,
+		Default:   exampleValue(p.Default, schema.Type),
+	}
+
+	if schema.Format == "" {
+		facets.Format = p.Format
+	}
+
+	return &Parameter{
+		Name:        p.Name,
+		In:          in,
+		Description: p.Description,
+		Required:    p.Required,
+		Schema:      decorate(schema, facets),
+		Example:     example,
+	}
+}
+
+// transactions Converts the transactions into the operation's request body, header's parameters and responses.
+// The bodies of the same media type are merged, their examples are kept as named examples
+func (c *converter) transactions(op *Operation, transactions []definition.Transaction, where string) {
+	for _, t := range transactions {
+		for _, h := range t.Request.Headers {
+			if containsFold(ignoredHeaders, h.Name) || hasParameter(op.Parameters, h.Name, "header") {
+				continue
+			}
+
+			op.Parameters = append(op.Parameters, &Parameter{
+				Name:        h.Name,
+				In:          "header",
+				Description: h.Description,
+				Schema:      &Schema{Type: "string"},
+				Example:     h.Example,
+			})
+		}
+
+		if len(t.Request.Body) > 0 {
+			if op.RequestBody == nil {
+				op.RequestBody = &RequestBody{Content: make(map[string]*MediaType)}
+			}
+
+			if op.RequestBody.Description == "" {
+				op.RequestBody.Description = t.Request.Description
+			}
+
+			c.content(op.RequestBody.Content, t.Request.Body, t.Request.Title, where+" request")
+		}
+
+		if t.Response.StatusCode == 0 && t.Response.Description == "" && len(t.Response.Body) == 0 && len(t.Response.Headers) == 0 {
+			continue
+		}
+
+		code := "default"
+		if t.Response.StatusCode > 0 {
+			code = strconv.Itoa(t.Response.StatusCode)
+		}
+
+		resp, ok := op.Responses[code]
+		if !ok {
+			resp = &Response{Description: t.Response.Description}
+			op.Responses[code] = resp
+		}
+
+		if resp.Description == "" {
+			resp.Description = t.Response.Description
+		}
+
+		for _, h := range t.Response.Headers {
+			if strings.EqualFold(h.Name, "Content-Type") {
+				continue
+			}
+
+			if resp.Headers == nil {
+				resp.Headers = make(map[string]*Header)
+			}
+
+			if _, ok := resp.Headers[h.Name]; !ok {
+				resp.Headers[h.Name] = &Header{Description: h.Description, Schema: &Schema{Type: "string"}, Example: h.Example}
+			}
+		}
+
+		if len(t.Response.Body) > 0 {
+			if resp.Content == nil {
+				resp.Content = make(map[string]*MediaType)
+			}
+
+			c.content(resp.Content, t.Response.Body, "", fmt.Sprintf("%s %s response", where, code))
+		}
+	}
+
+	// The responses' descriptions are required
+	for code, resp := range op.Responses {
+		if resp.Description != "" {
+			continue
+		}
+
+		status, _ := strconv.Atoi(code)
+		if resp.Description = http.StatusText(status); resp.Description == "" {
+			resp.Description = "Default response"
+		}
+	}
+
+	if len(op.Responses) == 0 {
+		c.warn("%s: the operation has no responses, OpenAPI requires one so a default response is declared", where)
+		op.Responses["default"] = &Response{Description: "Default response"}
+	}
+}
+
+// content Merges the bodies into the content, by their media types. The name is the examples' name, if given
+func (c *converter) content(content map[string]*MediaType, bodies []definition.Body, name string, where string) {
+	for _, b := range bodies {
+		mediaType := string(b.MediaType)
+		if mediaType == "" {
+			mediaType = c.defaultMediaType()
+		}
+
+		schema := c.bodySchema(b, where)
+
+		examples := namedExamples(b, mediaType, name)
+
+		mt, ok := content[mediaType]
+		if !ok {
+			mt = &MediaType{Schema: schema}
+			content[mediaType] = mt
+		} else if !reflect.DeepEqual(mt.Schema, schema) {
+			c.warn("%s: the %s bodies have different schemas, only the first one is kept", where, mediaType)
+		}
+
+		mt.addExamples(examples)
+	}
+}
+
+// defaultMediaType Returns the api's default media type, the bodies without media type are described by it
+func (c *converter) defaultMediaType() string {
+	if len(c.def.MediaTypes) > 0 {
+		return string(c.def.MediaTypes[0])
+	}
+	return "*/*"
+}
+
+// namedExamples Returns the body's examples by their names, the unnamed ones are named by the name given
+func namedExamples(b definition.Body, mediaType string, name string) (examples []namedExample) {
+	for _, e := range b.NamedExamples {
+		examples = append(examples, namedExample{
+			name: e.Name,
+			example: &Example{
+				Summary:     e.DisplayName,
+				Description: e.Description,
+				Value:       exampleValue(e.Value, mediaTypeSchemaType(mediaType)),
+			},
+		})
+	}
+
+	if len(examples) == 0 && b.Example != "" {
+		examples = append(examples, namedExample{name: name, example: &Example{Value: exampleValue(b.Example, mediaTypeSchemaType(mediaType))}})
+	}
+
+	return
+}
+
+// namedExample Represents an example with its name, empty if it's unnamed
+type namedExample struct {
+	name    string
+	example *Example
+}
+
+// addExamples Adds the examples to the media type, a single unnamed example is its example otherwise they're named examples
+func (mt *MediaType) addExamples(examples []namedExample) {
+	for _, e := range examples {
+		if mt.Example == nil && mt.Examples == nil && e.name == "" {
+			mt.Example = e.example.Value
+			continue
+		}
+
+		if mt.Examples == nil {
+			mt.Examples = make(map[string]*Example)
+			if mt.Example != nil {
+				mt.Examples["example1"] = &Example{Value: mt.Example}
+				mt.Example = nil
+			}
+		}
+
+		name := e.name
+		for i := len(mt.Examples) + 1; name == "" || mt.Examples[name] != nil; i++ {
+			name = fmt.Sprintf("example%d", i)
+		}
+
+		mt.Examples[name] = e.example
+	}
+}
+
+// mediaTypeSchemaType Returns object for the JSON's media types, so their examples are parsed, string otherwise
+func mediaTypeSchemaType(mediaType string) string {
+	if strings.Contains(mediaType, "json") {
+		return "object"
+	}
+	return "string"
+}
+
+// exampleValue Returns the example's (or default's) value typed by the schema's type, the strings given for other types are parsed as JSON
+func exampleValue(example interface{}, typ string) interface{} {
+	s, ok := example.(string)
+	if ok && s == "" {
+		return nil
+	}
+
+	if !ok || typ == "string" || typ == "" {
+		return example
+	}
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(s), &value); err == nil {
+		return value
+	}
+
+	return s
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasParameter(params []*Parameter, name, in string) bool {
+	for _, p := range params {
+		if p.In == in && strings.EqualFold(p.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,214 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/definition"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvert_Paths(t *testing.T) {
+	t.Parallel()
+
+	def := definition.Api{
+		Title:             "Notes API",
+		Version:           "v1",
+		BaseURI:           "api.example.com/{version}",
+		Protocols:         []definition.Protocol{"HTTPS"},
+		BaseURIParameters: []definition.Parameter{{Name: "version", Type: "string"}},
+		ResourceGroups: []definition.ResourceGroup{
+			{
+				Title: "Notes",
+				Resources: []definition.Resource{
+					{
+						Title: "Note",
+						Href: definition.Href{
+							FullPath:   "/notes/{id}{?fields}",
+							Parameters: []definition.Parameter{{Name: "id", Type: "number", Example: "42"}, {Name: "fields", Type: "string"}},
+						},
+						Actions: []definition.ResourceAction{
+							{
+								Method:      "GET",
+								Title:       "Retrieve a Note",
+								Annotations: definition.Annotations{{Name: "deprecated"}, {Name: "internal"}},
+								Transactions: []definition.Transaction{
+									{
+										Request:  definition.Request{Headers: []definition.Header{{Name: "Accept", Example: "application/json"}, {Name: "X-Trace"}}},
+										Response: definition.Response{StatusCode: 200, Body: []definition.Body{{MediaType: "application/json", Example: `{"id": 42}`}}},
+									},
+									{Response: definition.Response{StatusCode: 200, Body: []definition.Body{{MediaType: "application/json", Example: `{"id": 43}`}}}},
+								},
+							},
+							{Method: "CONNECT"},
+						},
+						Resources: []definition.Resource{
+							{
+								Href: definition.Href{FullPath: "/notes/{id}/tags/{tag}"},
+								Actions: []definition.ResourceAction{
+									{Method: "DELETE", Transactions: []definition.Transaction{{Response: definition.Response{StatusCode: 204}}}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	doc, warnings := Convert(def)
+
+	assert.Exactly(t, []Server{{URL: "https://api.example.com/{version}", Variables: map[string]*ServerVariable{"version": {Default: "v1"}}}}, doc.Servers)
+	assert.Exactly(t, []Tag{{Name: "Notes"}}, doc.Tags)
+
+	get := doc.Paths["/notes/{id}"].Get
+	if !assert.NotNil(t, get) {
+		return
+	}
+
+	assert.Exactly(t, []string{"Notes"}, get.Tags)
+	assert.True(t, get.Deprecated)
+	assert.Exactly(t, []*Parameter{
+		{Name: "id", In: "path", Required: true, Schema: &Schema{Type: "number"}, Example: 42.0},
+		{Name: "fields", In: "query", Schema: &Schema{Type: "string"}},
+		{Name: "X-Trace", In: "header", Schema: &Schema{Type: "string"}},
+	}, get.Parameters)
+
+	// The examples of the same media type are named
+	assert.Exactly(t, map[string]*Response{
+		"200": {
+			Description: "OK",
+			Content: map[string]*MediaType{
+				"application/json": {
+					Examples: map[string]*Example{
+						"example1": {Value: map[string]interface{}{"id": 42.0}},
+						"example2": {Value: map[string]interface{}{"id": 43.0}},
+					},
+				},
+			},
+		},
+	}, get.Responses)
+
+	// The parameters of the parent resource are inherited
+	del := doc.Paths["/notes/{id}/tags/{tag}"].Delete
+	if assert.NotNil(t, del) {
+		assert.Exactly(t, []*Parameter{
+			{Name: "id", In: "path", Required: true, Schema: &Schema{Type: "number"}, Example: 42.0},
+			{Name: "tag", In: "path", Required: true, Schema: &Schema{Type: "string"}},
+			{Name: "fields", In: "query", Schema: &Schema{Type: "string"}},
+		}, del.Parameters)
+		assert.Exactly(t, map[string]*Response{"204": {Description: "No Content"}}, del.Responses)
+	}
+
+	assert.Exactly(t, []string{
+		"CONNECT /notes/{id}: the method isn't supported by OpenAPI",
+		"the annotations (internal) aren't converted, OpenAPI has no equivalent of them",
+	}, warnings)
+}
+
+func TestConvert_Schemas(t *testing.T) {
+	t.Parallel()
+
+	min := 1
+	def := definition.Api{
+		CustomTypes: []definition.CustomType{
+			{
+				Name:        "Note",
+				Description: "A note",
+				Kind:        definition.ObjectKind,
+				Properties: []definition.CustomTypeProperty{
+					{Name: "id", Type: "integer", Required: true, Example: "42"},
+					{Name: "title", Type: "string", MinLength: &min},
+					{Name: "author", Type: "User | nil", Description: "The author"},
+					{Name: "tags", Type: "(Tag | string)[]"},
+					{Name: "createdAt", Type: "datetime"},
+				},
+			},
+			{Name: "User", Kind: definition.ObjectKind, Properties: []definition.CustomTypeProperty{{Name: "name", Type: "Name"}}},
+			{Name: "Tag", Type: "string", Kind: definition.ScalarKind, Enum: []interface{}{"home", "work"}},
+			{Name: "Notes", Type: "Note[]", Kind: definition.ArrayKind, Items: &definition.CustomType{Name: "Note"}},
+		},
+	}
+
+	doc, warnings := Convert(def)
+
+	if !assert.NotNil(t, doc.Components) {
+		return
+	}
+
+	ref := func(name string) *Schema { return &Schema{Ref: "#/components/schemas/" + name} }
+
+	assert.Exactly(t, map[string]*Schema{
+		"Note": {
+			Type:        "object",
+			Description: "A note",
+			Properties: map[string]*Schema{
+				"id":        {Type: "integer", Example: 42.0},
+				"title":     {Type: "string", MinLength: &min},
+				"author":    {Description: "The author", AllOf: []*Schema{ref("User")}, Nullable: true},
+				"tags":      {Type: "array", Items: &Schema{OneOf: []*Schema{ref("Tag"), {Type: "string"}}}},
+				"createdAt": {Type: "string", Format: "date-time"},
+			},
+			Required: []string{"id"},
+		},
+		"User":  {Type: "object", Properties: map[string]*Schema{"name": {}}},
+		"Tag":   {Type: "string", Enum: []interface{}{"home", "work"}},
+		"Notes": {Type: "array", Items: ref("Note")},
+	}, doc.Components.Schemas)
+
+	assert.Exactly(t, []string{"the type User: the type Name isn't declared, it's converted as any type"}, warnings)
+}
+
+func TestConvert_SecuritySchemes(t *testing.T) {
+	t.Parallel()
+
+	def := definition.Api{
+		SecuritySchemes: []definition.SecurityScheme{
+			{
+				Name: "oauth_2_0",
+				Type: "OAuth 2.0",
+				Settings: []definition.SecuritySchemeSetting{
+					{Name: "accessTokenUri", Data: "https://example.com/token"},
+					{Name: "authorizationGrants", Data: []interface{}{"client_credentials", "urn:custom"}},
+					{Name: "authorizationUri", Data: "https://example.com/authorize"},
+					{Name: "scopes", Data: []interface{}{"notes"}},
+				},
+			},
+			{Name: "basic", Type: "Basic Authentication", Description: "Basic"},
+			{
+				Name:         "token",
+				Type:         "Pass Through",
+				Transactions: []definition.Transaction{{Request: definition.Request{Headers: []definition.Header{{Name: "X-Token"}}}}},
+			},
+			{Name: "oauth_1_0", Type: "OAuth 1.0"},
+		},
+		SecuredBy: []definition.Option{
+			{Name: "oauth_2_0", Parameters: map[string]interface{}{"scopes": []interface{}{"notes"}}},
+			{Name: "oauth_1_0"},
+			{Name: "null"},
+		},
+	}
+
+	doc, warnings := Convert(def)
+
+	if !assert.NotNil(t, doc.Components) {
+		return
+	}
+
+	assert.Exactly(t, map[string]*SecurityScheme{
+		"oauth_2_0": {
+			Type: "oauth2",
+			Flows: &OAuthFlows{
+				ClientCredentials: &OAuthFlow{TokenURL: "https://example.com/token", Scopes: map[string]string{"notes": ""}},
+			},
+		},
+		"basic": {Type: "http", Scheme: "basic", Description: "Basic"},
+		"token": {Type: "apiKey", In: "header", Name: "X-Token"},
+	}, doc.Components.SecuritySchemes)
+
+	assert.Exactly(t, []SecurityRequirement{{"oauth_2_0": {"notes"}}, {}}, doc.Security)
+
+	assert.Exactly(t, []string{
+		"the security scheme oauth_2_0: its grant urn:custom can't be represented",
+		"the security scheme oauth_1_0: its type OAuth 1.0 can't be represented",
+	}, warnings)
+}
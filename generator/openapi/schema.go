@@ -0,0 +1,351 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/definition"
+)
+
+// componentNameRe Matches the characters which can't be used by the components' names
+var componentNameRe = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// scalarSchemas The schemas of RAML's and MSON's built-in types
+var scalarSchemas = map[string]Schema{
+	"":              {},
+	"any":           {},
+	"string":        {Type: "string"},
+	"number":        {Type: "number"},
+	"integer":       {Type: "integer"},
+	"boolean":       {Type: "boolean"},
+	"object":        {Type: "object"},
+	"array":         {Type: "array"},
+	"enum":          {Type: "string"},
+	"date-only":     {Type: "string", Format: "date"},
+	"time-only":     {Type: "string", Format: "time"},
+	"datetime-only": {Type: "string", Format: "datetime-only"},
+	"datetime":      {Type: "string", Format: "date-time"},
+	"file":          {Type: "string", Format: "binary"},
+}
+
+// components Converts the custom types into the components' schemas and the security schemes
+func (c *converter) components() {
+	schemas := make(map[string]*Schema)
+	for _, ct := range c.def.CustomTypes {
+		name := componentName(ct.Name)
+		if _, ok := schemas[name]; ok {
+			c.warn("the type %s is declared more than once, only the first one is kept", ct.Name)
+			continue
+		}
+
+		schemas[name] = c.customTypeSchema(ct, "the type "+ct.Name)
+	}
+
+	schemes := c.securitySchemes()
+
+	if len(schemas) == 0 && len(schemes) == 0 {
+		return
+	}
+
+	c.doc.Components = &Components{}
+	if len(schemas) > 0 {
+		c.doc.Components.Schemas = schemas
+	}
+	if len(schemes) > 0 {
+		c.doc.Components.SecuritySchemes = schemes
+	}
+}
+
+// componentName Returns the component's name of the type, the characters which can't be used are replaced by _
+func componentName(name string) string {
+	return componentNameRe.ReplaceAllString(name, "_")
+}
+
+// isDeclared Tells if the type is one of the api's custom types
+func (c *converter) isDeclared(name string) bool {
+	for _, ct := range c.def.CustomTypes {
+		if ct.Name == name && name != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// typeSchema Returns the schema of the type's expression, e.g. Note, Note[], string | nil.
+// The custom types are referenced, inline JSON schemas are read as they're given
+func (c *converter) typeSchema(expr string, where string) *Schema {
+	expr = unwrap(strings.TrimSpace(expr))
+
+	if members := splitUnion(expr); len(members) > 1 {
+		var (
+			schemas  []*Schema
+			nullable bool
+		)
+
+		for _, member := range members {
+			if member == "nil" {
+				nullable = true
+				continue
+			}
+			schemas = append(schemas, c.typeSchema(member, where))
+		}
+
+		s := &Schema{OneOf: schemas}
+		if len(schemas) == 1 {
+			s = schemas[0]
+		}
+
+		if nullable {
+			s = decorate(s, Schema{Nullable: true})
+		}
+
+		return s
+	}
+
+	if strings.HasSuffix(expr, "[]") {
+		return &Schema{Type: "array", Items: c.typeSchema(strings.TrimSuffix(expr, "[]"), where)}
+	}
+
+	if s, ok := scalarSchemas[expr]; ok {
+		if s.Type == "array" {
+			s.Items = &Schema{}
+		}
+		return &s
+	}
+
+	if c.isDeclared(expr) {
+		return &Schema{Ref: "#/components/schemas/" + componentName(expr)}
+	}
+
+	s := &Schema{}
+
+	switch {
+	case strings.HasPrefix(expr, "{"):
+		if err := json.Unmarshal([]byte(expr), s); err != nil {
+			s = &Schema{}
+			c.warn("%s: the inline JSON schema can't be read, it's converted as any type: %s", where, err)
+		}
+	case expr == "nil":
+		c.warn("%s: the type nil can't be represented on its own, it's converted as any type", where)
+	default:
+		c.warn("%s: the type %s isn't declared, it's converted as any type", where, expr)
+	}
+
+	return s
+}
+
+// unwrap Removes the parentheses wrapping the whole expression, e.g. (string | number)
+func unwrap(expr string) string {
+	for strings.HasPrefix(expr, "(") && strings.HasSuffix(expr, ")") {
+		depth := 0
+		for i, r := range expr {
+			switch r {
+			case '(':
+				depth++
+			case ')':
+				depth--
+			}
+
+			// The first parenthesis is closed before the end, e.g. (a) | (b)
+			if depth == 0 && i < len(expr)-1 {
+				return expr
+			}
+		}
+
+		expr = strings.TrimSpace(expr[1 : len(expr)-1])
+	}
+
+	return expr
+}
+
+// splitUnion Returns the members of the union's expression, the unions nested in parentheses aren't split
+func splitUnion(expr string) (members []string) {
+	depth, start := 0, 0
+	for i, r := range expr {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case '|':
+			if depth == 0 {
+				members = append(members, strings.TrimSpace(expr[start:i]))
+				start = i + 1
+			}
+		}
+	}
+
+	return append(members, strings.TrimSpace(expr[start:]))
+}
+
+// customTypeSchema Returns the schema of the custom type, described by its resolved kind
+func (c *converter) customTypeSchema(ct definition.CustomType, where string) (s *Schema) {
+	typ, _ := ct.Type.(string)
+
+	switch ct.Kind {
+	case definition.ObjectKind:
+		s = c.objectSchema(ct.Properties, where)
+	case definition.ArrayKind:
+		s = &Schema{Type: "array", Items: &Schema{}}
+		if ct.Items != nil {
+			s.Items = c.refOrSchema(*ct.Items, where)
+		} else if strings.HasSuffix(typ, "[]") {
+			s = c.typeSchema(typ, where)
+		}
+	case definition.UnionKind:
+		var nullable bool
+		s = &Schema{}
+		for _, member := range ct.Members {
+			if member.Type == "nil" {
+				nullable = true
+				continue
+			}
+			s.OneOf = append(s.OneOf, c.refOrSchema(member, where))
+		}
+
+		if len(s.OneOf) == 1 {
+			s = s.OneOf[0]
+		}
+
+		if nullable {
+			s = decorate(s, Schema{Nullable: true})
+		}
+	default:
+		if len(ct.Properties) > 0 {
+			s = c.objectSchema(ct.Properties, where)
+		} else {
+			s = c.typeSchema(typ, where)
+		}
+	}
+
+	facets := Schema{Description: ct.Description, Default: ct.Default}
+
+	if enum, ok := ct.Enum.([]interface{}); ok {
+		facets.Enum = enum
+	}
+
+	if len(ct.Examples) > 0 {
+		facets.Example = ct.Examples[0]
+	} else if len(ct.NamedExamples) > 0 {
+		facets.Example = ct.NamedExamples[0].Value
+	}
+
+	return decorate(s, facets)
+}
+
+// refOrSchema References the custom type if it's declared by the api, its schema is returned otherwise
+func (c *converter) refOrSchema(ct definition.CustomType, where string) *Schema {
+	if c.isDeclared(ct.Name) {
+		return &Schema{Ref: "#/components/schemas/" + componentName(ct.Name)}
+	}
+
+	return c.customTypeSchema(ct, where)
+}
+
+// objectSchema Returns the object's schema of the properties, the required ones are listed in their order
+func (c *converter) objectSchema(props []definition.CustomTypeProperty, where string) *Schema {
+	s := &Schema{Type: "object"}
+
+	for _, p := range props {
+		if s.Properties == nil {
+			s.Properties = make(map[string]*Schema)
+		}
+
+		s.Properties[p.Name] = c.propertySchema(p, where)
+
+		if p.Required {
+			s.Required = append(s.Required, p.Name)
+		}
+	}
+
+	return s
+}
+
+// propertySchema Returns the schema of the property, restricted by its facets
+func (c *converter) propertySchema(p definition.CustomTypeProperty, where string) (s *Schema) {
+	switch {
+	case len(p.Properties) > 0 && strings.HasSuffix(p.Type, "[]"):
+		s = &Schema{Type: "array", Items: c.objectSchema(p.Properties, where)}
+	case len(p.Properties) > 0:
+		s = c.objectSchema(p.Properties, where)
+	default:
+		s = c.typeSchema(p.Type, where)
+	}
+
+	if p.DiscriminatorValue != "" {
+		c.warn("%s: the discriminator value %s of the property %s can't be represented", where, p.DiscriminatorValue, p.Name)
+	}
+
+	exampleType := s.Type
+	if s.Ref != "" {
+		exampleType = "object"
+	}
+
+	facets := Schema{
+		Description:          p.Description,
+		Enum:                 p.Enum,
+		Default:              exampleValue(p.Default, exampleType),
+		Example:              exampleValue(p.Example, exampleType),
+		Pattern:              stringValue(p.Pattern),
+		MinLength:            p.MinLength,
+		MaxLength:            p.MaxLength,
+		Minimum:              p.Min,
+		Maximum:              p.Max,
+		MultipleOf:           p.MultipleOf,
+		MinItems:             p.MinItems,
+		MaxItems:             p.MaxItems,
+		UniqueItems:          p.UniqueItems,
+		MinProperties:        p.MinProperties,
+		MaxProperties:        p.MaxProperties,
+		AdditionalProperties: p.AdditionalProperties,
+	}
+
+	// The format of the built-in types (e.g. datetime) prevails
+	if s.Format == "" {
+		facets.Format = p.Format
+	}
+
+	if p.Discriminator != "" {
+		facets.Discriminator = &Discriminator{PropertyName: p.Discriminator}
+	}
+
+	return decorate(s, facets)
+}
+
+// bodySchema Returns the schema of the body, the custom types are referenced
+func (c *converter) bodySchema(b definition.Body, where string) *Schema {
+	switch {
+	case b.Type != "" && (b.CustomType == nil || c.isDeclared(definition.CleanCustomTypeName(b.Type))):
+		return c.typeSchema(b.Type, where)
+	case b.CustomType != nil:
+		return c.customTypeSchema(*b.CustomType, where)
+	}
+
+	return nil
+}
+
+// decorate Sets the facets given on the schema. References can't have siblings, so they're wrapped by allOf
+func decorate(s *Schema, facets Schema) *Schema {
+	if reflect.DeepEqual(facets, Schema{}) {
+		return s
+	}
+
+	if s.Ref != "" {
+		facets.AllOf = []*Schema{s}
+		return &facets
+	}
+
+	dst := reflect.ValueOf(s).Elem()
+	src := reflect.ValueOf(facets)
+
+	for i := 0; i < src.NumField(); i++ {
+		field := src.Field(i)
+		if !reflect.DeepEqual(field.Interface(), reflect.Zero(field.Type()).Interface()) {
+			dst.Field(i).Set(field)
+		}
+	}
+
+	return s
+}
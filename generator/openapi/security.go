@@ -0,0 +1,214 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/definition"
+)
+
+// securitySchemes Converts the api's security schemes, RAML's and Swagger's types are mapped to OpenAPI's ones
+func (c *converter) securitySchemes() map[string]*SecurityScheme {
+	schemes := make(map[string]*SecurityScheme)
+
+	for _, s := range c.def.SecuritySchemes {
+		scheme := c.securityScheme(s)
+		if scheme != nil {
+			schemes[componentName(s.Name)] = scheme
+		}
+
+		c.schemes[s.Name] = scheme != nil
+	}
+
+	return schemes
+}
+
+// securityScheme Returns the OpenAPI's security scheme, nil if the scheme can't be represented
+func (c *converter) securityScheme(s definition.SecurityScheme) (scheme *SecurityScheme) {
+	where := "the security scheme " + s.Name
+
+	settings := make(map[string]interface{})
+	for _, setting := range s.Settings {
+		settings[setting.Name] = setting.Data
+	}
+
+	switch s.Type {
+	case "apiKey", "http", "openIdConnect":
+		// OpenAPI's schemes keep their fields as settings
+		settings["type"] = s.Type
+		scheme = &SecurityScheme{}
+		if err := remarshal(settings, scheme); err != nil {
+			c.warn("%s: its settings are invalid: %s", where, err)
+			return nil
+		}
+	case "basic", "Basic Authentication":
+		scheme = &SecurityScheme{Type: "http", Scheme: "basic"}
+	case "Digest Authentication":
+		scheme = &SecurityScheme{Type: "http", Scheme: "digest"}
+	case "oauth2", "OAuth 2.0":
+		flows := c.oauthFlows(settings, where)
+		if flows == nil {
+			return nil
+		}
+		scheme = &SecurityScheme{Type: "oauth2", Flows: flows}
+	case "Pass Through":
+		var headers []definition.Header
+		for _, t := range s.Transactions {
+			headers = append(headers, t.Request.Headers...)
+		}
+
+		if len(headers) == 0 {
+			c.warn("%s: it isn't described by a header, OpenAPI's api keys are", where)
+			return nil
+		}
+
+		if len(headers) > 1 {
+			c.warn("%s: it's described by %d headers, OpenAPI's api keys are described by one so only %s is kept", where, len(headers), headers[0].Name)
+		}
+
+		scheme = &SecurityScheme{Type: "apiKey", In: "header", Name: headers[0].Name}
+	default:
+		c.warn("%s: its type %s can't be represented", where, s.Type)
+		return nil
+	}
+
+	scheme.Description = s.Description
+
+	return
+}
+
+// oauthFlows Returns the OAuth 2.0's flows described by OpenAPI's flows, Swagger's flow or RAML's grants
+func (c *converter) oauthFlows(settings map[string]interface{}, where string) (flows *OAuthFlows) {
+	if v, ok := settings["flows"]; ok {
+		flows = &OAuthFlows{}
+		if err := remarshal(v, flows); err != nil {
+			c.warn("%s: its flows are invalid: %s", where, err)
+			return nil
+		}
+		return
+	}
+
+	flow := func() *OAuthFlow {
+		f := &OAuthFlow{
+			AuthorizationURL: first(settings, "authorizationUrl", "authorizationUri"),
+			TokenURL:         first(settings, "tokenUrl", "accessTokenUri"),
+			Scopes:           make(map[string]string),
+		}
+
+		// Swagger's scopes are described by their descriptions, RAML's ones are listed
+		switch scopes := settings["scopes"].(type) {
+		case map[string]interface{}:
+			for name, desc := range scopes {
+				f.Scopes[name] = fmt.Sprint(desc)
+			}
+		case []interface{}:
+			for _, name := range scopes {
+				f.Scopes[fmt.Sprint(name)] = ""
+			}
+		}
+
+		return f
+	}
+
+	var grants []string
+	switch v := settings["authorizationGrants"].(type) {
+	case []interface{}:
+		for _, grant := range v {
+			grants = append(grants, fmt.Sprint(grant))
+		}
+	case string:
+		grants = []string{v}
+	}
+
+	if flow, ok := settings["flow"].(string); ok {
+		grants = append(grants, flow)
+	}
+
+	// The grants aren't listed by RAML 0.8's schemes, the urls tell the flow
+	if len(grants) == 0 {
+		switch {
+		case first(settings, "authorizationUri") != "":
+			grants = []string{"authorization_code"}
+		case first(settings, "accessTokenUri") != "":
+			grants = []string{"client_credentials"}
+		}
+	}
+
+	flows = &OAuthFlows{}
+	for _, grant := range grants {
+		switch grant {
+		case "authorization_code", "code", "accessCode":
+			flows.AuthorizationCode = flow()
+		case "implicit", "token":
+			flows.Implicit = flow()
+			flows.Implicit.TokenURL = ""
+		case "password", "owner":
+			flows.Password = flow()
+			flows.Password.AuthorizationURL = ""
+		case "client_credentials", "credentials", "application":
+			flows.ClientCredentials = flow()
+			flows.ClientCredentials.AuthorizationURL = ""
+		default:
+			c.warn("%s: its grant %s can't be represented", where, grant)
+		}
+	}
+
+	if *flows == (OAuthFlows{}) {
+		c.warn("%s: it has no flow OpenAPI can represent", where)
+		return nil
+	}
+
+	return
+}
+
+// security Returns the security requirements of the options, each option is an alternative. The option null makes the security optional
+func (c *converter) security(opts []definition.Option, where string) (requirements []SecurityRequirement) {
+	for _, opt := range opts {
+		if opt.Name == "null" || opt.Name == "" {
+			requirements = append(requirements, SecurityRequirement{})
+			continue
+		}
+
+		// The schemes which couldn't be converted are already reported, the undeclared ones are reported once
+		converted, ok := c.schemes[opt.Name]
+		if !ok {
+			c.warn("%s: it's secured by %s, which isn't a declared security scheme", where, opt.Name)
+			c.schemes[opt.Name] = false
+		}
+
+		if !converted {
+			continue
+		}
+
+		scopes := []string{}
+		if v, ok := opt.Parameters["scopes"].([]interface{}); ok {
+			for _, scope := range v {
+				scopes = append(scopes, fmt.Sprint(scope))
+			}
+		}
+
+		requirements = append(requirements, SecurityRequirement{componentName(opt.Name): scopes})
+	}
+
+	return
+}
+
+// first Returns the first of the settings given as a string, empty if none is
+func first(settings map[string]interface{}, names ...string) string {
+	for _, name := range names {
+		if s, ok := settings[name].(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// remarshal Decodes the value into the struct given through JSON, the settings are read as the OpenAPI's fields
+func remarshal(v interface{}, dst interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, dst)
+}
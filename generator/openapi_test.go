@@ -0,0 +1,58 @@
+package generator
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/generator/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate_OpenAPI_Integration(t *testing.T) {
+	outputDir, err := ioutil.TempDir("", "")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	// Clean up
+	defer func() {
+		os.RemoveAll(outputDir)
+	}()
+
+	// The document is named openapi.yaml when the configuration doesn't give an output
+	gen, err := NewGenerator(config.NewConfig(false, "", outputDir, "", nil, config.FormatOpenAPI), testJSONData())
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.IsType(t, &OpenAPI{}, gen)
+	assert.Nil(t, gen.Generate())
+	assert.Empty(t, gen.(Reporter).Warnings())
+
+	expected, err := testLoadFile("testdata/openapi/openapi.yaml")
+	assert.Nil(t, err)
+
+	output, err := testLoadFile(filepath.Join(outputDir, OpenAPIDefaultOutput))
+	assert.Nil(t, err)
+
+	assert.Exactly(t, expected, output)
+
+	// The document is written as JSON for the .json's outputs
+	gen, err = NewGenerator(config.NewConfig(false, "", outputDir, "openapi.json", nil, config.FormatOpenAPI), testJSONData())
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Nil(t, gen.Generate())
+
+	output, err = testLoadFile(filepath.Join(outputDir, "openapi.json"))
+	assert.Nil(t, err)
+
+	var doc map[string]interface{}
+	if assert.Nil(t, json.Unmarshal([]byte(output), &doc)) {
+		assert.Exactly(t, "3.0.3", doc["openapi"])
+	}
+}
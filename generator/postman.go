@@ -0,0 +1,75 @@
+package generator
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/definition"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/generator/config"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/generator/postman"
+)
+
+// PostmanDefaultOutput The name of the Postman's collection, when the configuration doesn't give an output
+const PostmanDefaultOutput = "api.postman_collection.json"
+
+// Postman Represents a Postman's generator, it converts the api's definition into a Postman's collection v2.1 and its environment
+type Postman struct {
+	output string
+	col    *postman.Collection
+	env    *postman.Environment
+}
+
+// NewPostmanGenerator Returns a Postman's generator, its collection is named api.postman_collection.json unless given.
+// The environment is written next to the collection, e.g. api.postman_environment.json
+func NewPostmanGenerator(cfg config.Config, data definition.Api) (gen Generator, err error) {
+	output := cfg.Output()
+	if filepath.Clean(output) == filepath.Clean(cfg.Dst()) {
+		output = filepath.Join(cfg.Dst(), PostmanDefaultOutput)
+	}
+
+	col, env := postman.Convert(data)
+
+	gen = &Postman{output: output, col: col, env: env}
+
+	return
+}
+
+// PostmanEnvironmentOutput Returns the environment's file of the collection's file.
+// e.g. api.postman_collection.json is api.postman_environment.json, api.json is api.postman_environment.json
+func PostmanEnvironmentOutput(output string) string {
+	if strings.HasSuffix(output, "postman_collection.json") {
+		return strings.TrimSuffix(output, "postman_collection.json") + "postman_environment.json"
+	}
+
+	return strings.TrimSuffix(output, filepath.Ext(output)) + ".postman_environment.json"
+}
+
+// Generate Writes the Postman's collection and its environment to the output's directory
+func (gen *Postman) Generate() (err error) {
+	if err = os.MkdirAll(filepath.Dir(gen.output), 0777); err != nil {
+		return
+	}
+
+	if err = writeFile(gen.output, gen.col.WriteJSON); err != nil {
+		return
+	}
+
+	err = writeFile(PostmanEnvironmentOutput(gen.output), gen.env.WriteJSON)
+
+	return
+}
+
+// writeFile Creates the file and writes it with the function given
+func writeFile(name string, write func(w io.Writer) error) (err error) {
+	var f *os.File
+	if f, err = os.Create(name); err != nil {
+		return
+	}
+	defer f.Close()
+
+	err = write(f)
+
+	return
+}
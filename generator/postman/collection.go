@@ -0,0 +1,138 @@
+package postman
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// SchemaURL The schema of the Postman's collections the collections follow, v2.1.0
+const SchemaURL = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+// BaseURLVariable The name of the variable holding the api's base uri, the requests' urls start with it
+const BaseURLVariable = "baseUrl"
+
+// Collection Represents a Postman's collection, its items are the folders and the requests
+type Collection struct {
+	Info     Info       `json:"info"`
+	Item     []*Item    `json:"item"`
+	Variable []Variable `json:"variable,omitempty"`
+}
+
+// Info Represents the collection's metadata
+type Info struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Schema      string `json:"schema"`
+}
+
+// Item Represents either a folder, holding items, or a request with its saved responses
+type Item struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description,omitempty"`
+	Item        []*Item     `json:"item,omitempty"`
+	Request     *Request    `json:"request,omitempty"`
+	Response    []*Response `json:"response,omitempty"`
+}
+
+// Request Represents a request, its url's variables are written {{name}}
+type Request struct {
+	Method      string   `json:"method"`
+	Header      []Header `json:"header"`
+	Body        *Body    `json:"body,omitempty"`
+	URL         URL      `json:"url"`
+	Description string   `json:"description,omitempty"`
+}
+
+// Header Represents a request's or response's header
+type Header struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Description string `json:"description,omitempty"`
+}
+
+// Body Represents a raw body, its language tells Postman how to highlight it
+type Body struct {
+	Mode    string       `json:"mode"`
+	Raw     string       `json:"raw"`
+	Options *BodyOptions `json:"options,omitempty"`
+}
+
+// BodyOptions Represents the options of the body's mode
+type BodyOptions struct {
+	Raw RawOptions `json:"raw"`
+}
+
+// RawOptions Represents the options of a raw body. e.g. json, xml, text
+type RawOptions struct {
+	Language string `json:"language"`
+}
+
+// URL Represents a request's url, the raw url and its parts
+type URL struct {
+	Raw   string   `json:"raw"`
+	Host  []string `json:"host"`
+	Path  []string `json:"path,omitempty"`
+	Query []Query  `json:"query,omitempty"`
+}
+
+// Query Represents a query's parameter, the optional ones are disabled
+type Query struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Description string `json:"description,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"`
+}
+
+// Variable Represents a collection's variable
+type Variable struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+}
+
+// Response Represents a saved example response of a request
+type Response struct {
+	Name            string   `json:"name"`
+	OriginalRequest *Request `json:"originalRequest"`
+	Status          string   `json:"status,omitempty"`
+	Code            int      `json:"code,omitempty"`
+	PreviewLanguage string   `json:"_postman_previewlanguage,omitempty"`
+	Header          []Header `json:"header"`
+	Body            string   `json:"body"`
+}
+
+// Environment Represents a Postman's environment, its values override the collection's variables of the same names
+type Environment struct {
+	Name   string             `json:"name"`
+	Values []EnvironmentValue `json:"values"`
+	Scope  string             `json:"_postman_variable_scope"`
+}
+
+// EnvironmentValue Represents an environment's variable
+type EnvironmentValue struct {
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+	Type    string `json:"type"`
+	Enabled bool   `json:"enabled"`
+}
+
+// WriteJSON Writes the collection as indented JSON
+func (col *Collection) WriteJSON(w io.Writer) error {
+	return writeJSON(w, col)
+}
+
+// WriteJSON Writes the environment as indented JSON
+func (env *Environment) WriteJSON(w io.Writer) error {
+	return writeJSON(w, env)
+}
+
+func writeJSON(w io.Writer, v interface{}) (err error) {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+
+	err = encoder.Encode(v)
+
+	return
+}
@@ -0,0 +1,402 @@
+package postman
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/definition"
+)
+
+// variableRe Matches the variables of the URI's template. e.g. {id}
+var variableRe = regexp.MustCompile(`\{([^}]+)\}`)
+
+// converter Holds the state of the conversion, the collection's variables are declared once by name
+type converter struct {
+	def       definition.Api
+	col       *Collection
+	variables map[string]bool
+}
+
+// Convert Returns the Postman's collection of the api's definition and the environment holding its base uri.
+// The resource groups are the collection's folders, each request is an action's request with its saved responses
+func Convert(def definition.Api) (col *Collection, env *Environment) {
+	c := &converter{
+		def: def,
+		col: &Collection{
+			Info: Info{Name: def.Title, Description: description(def), Schema: SchemaURL},
+			Item: []*Item{},
+		},
+		variables: make(map[string]bool),
+	}
+
+	c.baseURL()
+
+	// The environment holds the base uri and its parameters, the path's variables are kept by the collection
+	env = &Environment{Name: def.Title, Values: []EnvironmentValue{}, Scope: "environment"}
+	for _, v := range c.col.Variable {
+		env.Values = append(env.Values, EnvironmentValue{Key: v.Key, Value: v.Value, Type: "default", Enabled: true})
+	}
+
+	for _, g := range def.ResourceGroups {
+		var items []*Item
+		for _, r := range g.Resources {
+			items = append(items, c.resource(r, nil)...)
+		}
+
+		// The resources without group are written at the collection's root, the empty folders are skipped
+		switch {
+		case g.Title == "":
+			c.col.Item = append(c.col.Item, items...)
+		case len(items) > 0:
+			c.col.Item = append(c.col.Item, &Item{Name: g.Title, Description: g.Description, Item: items})
+		}
+	}
+
+	return c.col, env
+}
+
+// description Returns the api's description, its documentation's sections are written as markdown's headings
+func description(def definition.Api) string {
+	var sections []string
+	for _, s := range def.Documentation {
+		sections = append(sections, strings.TrimSpace(fmt.Sprintf("## %s\n\n%s", s.Title, s.Content)))
+	}
+
+	return strings.Join(sections, "\n\n")
+}
+
+// declare Declares the collection's variable, the first value given for a name is kept
+func (c *converter) declare(name, value, description string) {
+	if c.variables[name] {
+		return
+	}
+
+	c.variables[name] = true
+	c.col.Variable = append(c.col.Variable, Variable{Key: name, Value: value, Type: "string", Description: description})
+}
+
+// baseURL Declares the base uri's variable, its protocol is the first one declared if it has none.
+// The base uri's parameters are declared as variables too, e.g. https://{region}.example.com is https://{{region}}.example.com
+func (c *converter) baseURL() {
+	if c.def.BaseURI == "" {
+		c.declare(BaseURLVariable, "", "")
+		return
+	}
+
+	address := strings.TrimSuffix(c.def.BaseURI, "/")
+	if !strings.Contains(address, "://") && len(c.def.Protocols) > 0 {
+		address = strings.ToLower(string(c.def.Protocols[0])) + "://" + address
+	}
+
+	c.declare(BaseURLVariable, variableRe.ReplaceAllString(address, "{{$1}}"), "")
+
+	for _, m := range variableRe.FindAllStringSubmatch(address, -1) {
+		param := definition.Parameter{Name: m[1]}
+		for _, p := range c.def.BaseURIParameters {
+			if p.Name == m[1] {
+				param = p
+			}
+		}
+
+		value := parameterValue(param)
+		// RAML's {version} is the api's version, unless it's described
+		if value == "" && param.Name == "version" {
+			value = c.def.Version
+		}
+
+		c.declare(param.Name, value, param.Description)
+	}
+}
+
+// resource Converts the resource's actions into requests, the nested resources' requests follow them.
+// The parameters of the parent resources are inherited
+func (c *converter) resource(r definition.Resource, inherited []definition.Parameter) (items []*Item) {
+	params := mergeParameters(inherited, r.Href.Parameters)
+
+	path := r.Href.FullPath
+	if path == "" {
+		path = r.Href.Path
+	}
+
+	for _, a := range r.Actions {
+		actionPath := path
+		if a.Href.FullPath != "" {
+			actionPath = a.Href.FullPath
+		} else if a.Href.Path != "" {
+			actionPath = a.Href.Path
+		}
+
+		items = append(items, c.action(a, actionPath, mergeParameters(params, a.Href.Parameters))...)
+	}
+
+	for _, child := range r.Resources {
+		items = append(items, c.resource(child, params)...)
+	}
+
+	return
+}
+
+// action Converts the action into its requests, one by distinct request of its transactions.
+// The transactions which don't describe their request share the previous one, their responses are saved with it
+func (c *converter) action(a definition.ResourceAction, uri string, params []definition.Parameter) (items []*Item) {
+	path, pathVars, queryVars := definition.SplitURITemplate(uri)
+	url := c.url(path, pathVars, queryVars, params)
+	method := strings.ToUpper(a.Method)
+
+	name := a.Title
+	if name == "" {
+		name = method + " " + path
+	}
+
+	var (
+		item *Item
+		last definition.Request
+	)
+
+	for _, t := range a.Transactions {
+		empty := reflect.DeepEqual(t.Request, definition.Request{})
+
+		if item == nil || (!empty && !reflect.DeepEqual(t.Request, last)) {
+			itemName := name
+			switch {
+			case t.Request.Title != "":
+				itemName = fmt.Sprintf("%s (%s)", name, t.Request.Title)
+			case len(items) > 0:
+				itemName = fmt.Sprintf("%s (%d)", name, len(items)+1)
+			}
+
+			item = &Item{Name: itemName, Request: c.request(method, url, t.Request, a.Description)}
+			items = append(items, item)
+			last = t.Request
+		}
+
+		if resp := response(item.Request, t.Response); resp != nil {
+			item.Response = append(item.Response, resp)
+		}
+	}
+
+	if item == nil {
+		items = append(items, &Item{Name: name, Request: c.request(method, url, definition.Request{}, a.Description)})
+	}
+
+	return
+}
+
+// url Returns the request's url, its path's variables are declared as the collection's variables.
+// The optional query's parameters are disabled
+func (c *converter) url(path string, pathVars, queryVars []string, params []definition.Parameter) (url URL) {
+	byName := make(map[string]definition.Parameter, len(params))
+	for _, p := range params {
+		byName[p.Name] = p
+	}
+
+	for _, name := range pathVars {
+		p := byName[name]
+		c.declare(name, parameterValue(p), p.Description)
+	}
+
+	path = variableRe.ReplaceAllString(path, "{{$1}}")
+
+	url.Host = []string{"{{" + BaseURLVariable + "}}"}
+	for _, segment := range strings.Split(strings.Trim(path, "/"), "/") {
+		if segment != "" {
+			url.Path = append(url.Path, segment)
+		}
+	}
+
+	for _, p := range params {
+		if !contains(pathVars, p.Name) {
+			url.Query = append(url.Query, Query{Key: p.Name, Value: parameterValue(p), Description: p.Description, Disabled: !p.Required})
+		}
+	}
+
+	for _, name := range queryVars {
+		if _, ok := byName[name]; !ok {
+			url.Query = append(url.Query, Query{Key: name, Disabled: true})
+		}
+	}
+
+	var query []string
+	for _, q := range url.Query {
+		if !q.Disabled {
+			query = append(query, q.Key+"="+q.Value)
+		}
+	}
+
+	url.Raw = "{{" + BaseURLVariable + "}}" + path
+	if len(query) > 0 {
+		url.Raw += "?" + strings.Join(query, "&")
+	}
+
+	return
+}
+
+// request Returns the request of the method and url, its headers and body are the request's ones.
+// The first body is the request's body, its media type is the Content-Type's header if the request doesn't give it
+func (c *converter) request(method string, url URL, req definition.Request, desc string) *Request {
+	r := &Request{Method: method, Header: headers(req.Headers), URL: url, Description: desc}
+
+	if req.Description != "" {
+		r.Description = strings.TrimSpace(desc + "\n\n" + req.Description)
+	}
+
+	if len(req.Body) == 0 {
+		return r
+	}
+
+	b := req.Body[0]
+	mediaType := string(b.MediaType)
+	if mediaType == "" && len(c.def.MediaTypes) > 0 {
+		mediaType = string(c.def.MediaTypes[0])
+	}
+
+	r.Body = &Body{Mode: "raw", Raw: bodyExample(b), Options: &BodyOptions{Raw: RawOptions{Language: language(mediaType)}}}
+	r.Header = withContentType(r.Header, mediaType)
+
+	return r
+}
+
+// response Returns the saved response of the request, nil if the transaction doesn't describe its response
+func response(req *Request, resp definition.Response) *Response {
+	if reflect.DeepEqual(resp, definition.Response{}) {
+		return nil
+	}
+
+	r := &Response{
+		Name:            "Default response",
+		OriginalRequest: req,
+		Code:            resp.StatusCode,
+		Status:          http.StatusText(resp.StatusCode),
+		Header:          headers(resp.Headers),
+	}
+
+	if resp.StatusCode > 0 {
+		r.Name = strings.TrimSpace(fmt.Sprintf("%d %s", resp.StatusCode, r.Status))
+	}
+
+	if len(resp.Body) > 0 {
+		b := resp.Body[0]
+		r.Body = bodyExample(b)
+		r.PreviewLanguage = language(string(b.MediaType))
+		r.Header = withContentType(r.Header, string(b.MediaType))
+	}
+
+	return r
+}
+
+// headers Converts the headers, their examples are their values
+func headers(hs []definition.Header) []Header {
+	headers := []Header{}
+	for _, h := range hs {
+		headers = append(headers, Header{Key: h.Name, Value: value(h.Example), Description: h.Description})
+	}
+	return headers
+}
+
+// withContentType Adds the Content-Type's header of the media type, unless it's already given
+func withContentType(headers []Header, mediaType string) []Header {
+	if mediaType == "" {
+		return headers
+	}
+
+	for _, h := range headers {
+		if strings.EqualFold(h.Key, "Content-Type") {
+			return headers
+		}
+	}
+
+	return append(headers, Header{Key: "Content-Type", Value: mediaType})
+}
+
+// bodyExample Returns the body's example, its first named example if it has no example
+func bodyExample(b definition.Body) string {
+	if b.Example != "" || len(b.NamedExamples) == 0 {
+		return b.Example
+	}
+
+	if e := b.NamedExamples[0]; e.Content != "" {
+		return e.Content
+	}
+
+	return value(b.NamedExamples[0].Value)
+}
+
+// language Returns the Postman's language of the media type, which highlights the bodies
+func language(mediaType string) string {
+	switch {
+	case strings.Contains(mediaType, "json"):
+		return "json"
+	case strings.Contains(mediaType, "xml"):
+		return "xml"
+	case strings.Contains(mediaType, "html"):
+		return "html"
+	}
+	return "text"
+}
+
+// parameterValue Returns the parameter's example, its default or its first enum's value otherwise
+func parameterValue(p definition.Parameter) string {
+	for _, v := range []interface{}{p.Example, p.Default} {
+		if s := value(v); s != "" {
+			return s
+		}
+	}
+
+	if len(p.Enum) > 0 {
+		return value(p.Enum[0])
+	}
+
+	return ""
+}
+
+// value Returns the value as a string, the values which aren't scalars are written as JSON
+func value(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool, int, int64, float32, float64:
+		return fmt.Sprint(v)
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+
+	return string(b)
+}
+
+// mergeParameters Returns the parameters inherited overridden by the ones given, by their names
+func mergeParameters(inherited, params []definition.Parameter) (merged []definition.Parameter) {
+	merged = append(merged, inherited...)
+
+	for _, p := range params {
+		replaced := false
+		for i := range merged {
+			if merged[i].Name == p.Name {
+				merged[i], replaced = p, true
+			}
+		}
+
+		if !replaced {
+			merged = append(merged, p)
+		}
+	}
+
+	return
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,151 @@
+package postman
+
+import (
+	"testing"
+
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/definition"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvert_Requests(t *testing.T) {
+	t.Parallel()
+
+	def := definition.Api{
+		Title:             "Notes API",
+		Version:           "v1",
+		BaseURI:           "api.example.com/{version}/",
+		Protocols:         []definition.Protocol{"HTTPS"},
+		BaseURIParameters: []definition.Parameter{{Name: "version", Type: "string"}},
+		ResourceGroups: []definition.ResourceGroup{
+			{
+				Title: "Notes",
+				Resources: []definition.Resource{
+					{
+						Href: definition.Href{
+							FullPath:   "/notes/{id}{?fields}",
+							Parameters: []definition.Parameter{{Name: "id", Type: "number", Example: 42.0}, {Name: "fields", Type: "string", Default: "all"}},
+						},
+						Actions: []definition.ResourceAction{
+							{
+								Method: "PUT",
+								Title:  "Update a Note",
+								Transactions: []definition.Transaction{
+									{
+										Request: definition.Request{
+											Headers: []definition.Header{{Name: "X-Trace", Example: "abc"}},
+											Body:    []definition.Body{{MediaType: "application/json", Example: `{"body": "Buy milk"}`}},
+										},
+										Response: definition.Response{StatusCode: 200, Body: []definition.Body{{MediaType: "application/json", Example: `{"id": 42}`}}},
+									},
+									// The transactions without request share the previous one
+									{Response: definition.Response{StatusCode: 404}},
+									{
+										Request:  definition.Request{Title: "Empty", Body: []definition.Body{{MediaType: "application/json", Example: `{}`}}},
+										Response: definition.Response{StatusCode: 400},
+									},
+								},
+							},
+						},
+						Resources: []definition.Resource{
+							{
+								Href:    definition.Href{FullPath: "/notes/{id}/tags/{tag}", Parameters: []definition.Parameter{{Name: "fields", Required: true, Example: "name"}}},
+								Actions: []definition.ResourceAction{{Method: "delete"}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	col, env := Convert(def)
+
+	assert.Exactly(t, SchemaURL, col.Info.Schema)
+	assert.Exactly(t, []Variable{
+		{Key: "baseUrl", Value: "https://api.example.com/{{version}}", Type: "string"},
+		{Key: "version", Value: "v1", Type: "string"},
+		{Key: "id", Value: "42", Type: "string"},
+		{Key: "tag", Value: "", Type: "string"},
+	}, col.Variable)
+
+	// The environment holds the base uri and its parameters
+	assert.Exactly(t, &Environment{
+		Name: "Notes API",
+		Values: []EnvironmentValue{
+			{Key: "baseUrl", Value: "https://api.example.com/{{version}}", Type: "default", Enabled: true},
+			{Key: "version", Value: "v1", Type: "default", Enabled: true},
+		},
+		Scope: "environment",
+	}, env)
+
+	if !assert.Len(t, col.Item, 1) {
+		return
+	}
+
+	folder := col.Item[0]
+	assert.Exactly(t, "Notes", folder.Name)
+
+	if !assert.Len(t, folder.Item, 3) {
+		return
+	}
+
+	update := folder.Item[0]
+	assert.Exactly(t, "Update a Note", update.Name)
+	assert.Exactly(t, URL{
+		Raw:   "{{baseUrl}}/notes/{{id}}",
+		Host:  []string{"{{baseUrl}}"},
+		Path:  []string{"notes", "{{id}}"},
+		Query: []Query{{Key: "fields", Value: "all", Disabled: true}},
+	}, update.Request.URL)
+	assert.Exactly(t, []Header{{Key: "X-Trace", Value: "abc"}, {Key: "Content-Type", Value: "application/json"}}, update.Request.Header)
+	assert.Exactly(t, &Body{Mode: "raw", Raw: `{"body": "Buy milk"}`, Options: &BodyOptions{Raw: RawOptions{Language: "json"}}}, update.Request.Body)
+
+	if assert.Len(t, update.Response, 2) {
+		assert.Exactly(t, &Response{
+			Name:            "200 OK",
+			OriginalRequest: update.Request,
+			Status:          "OK",
+			Code:            200,
+			PreviewLanguage: "json",
+			Header:          []Header{{Key: "Content-Type", Value: "application/json"}},
+			Body:            `{"id": 42}`,
+		}, update.Response[0])
+		assert.Exactly(t, "404 Not Found", update.Response[1].Name)
+	}
+
+	// The distinct requests are named by their titles
+	empty := folder.Item[1]
+	assert.Exactly(t, "Update a Note (Empty)", empty.Name)
+	if assert.Len(t, empty.Response, 1) {
+		assert.Exactly(t, 400, empty.Response[0].Code)
+	}
+
+	// The nested resources inherit the parameters, the required query's parameters are enabled
+	del := folder.Item[2]
+	assert.Exactly(t, "DELETE /notes/{id}/tags/{tag}", del.Name)
+	assert.Exactly(t, "DELETE", del.Request.Method)
+	assert.Exactly(t, "{{baseUrl}}/notes/{{id}}/tags/{{tag}}?fields=name", del.Request.URL.Raw)
+	assert.Nil(t, del.Response)
+}
+
+func TestConvert_Ungrouped(t *testing.T) {
+	t.Parallel()
+
+	def := definition.Api{
+		Title: "Notes API",
+		ResourceGroups: []definition.ResourceGroup{
+			{Resources: []definition.Resource{{Href: definition.Href{FullPath: "/notes"}, Actions: []definition.ResourceAction{{Method: "GET"}}}}},
+			{Title: "Empty"},
+		},
+	}
+
+	col, env := Convert(def)
+
+	// The resources without group are at the root, the empty groups are skipped
+	if assert.Len(t, col.Item, 1) {
+		assert.Exactly(t, "GET /notes", col.Item[0].Name)
+		assert.Exactly(t, "{{baseUrl}}/notes", col.Item[0].Request.URL.Raw)
+	}
+
+	assert.Exactly(t, []EnvironmentValue{{Key: "baseUrl", Value: "", Type: "default", Enabled: true}}, env.Values)
+}
@@ -0,0 +1,54 @@
+package generator
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/generator/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate_Postman_Integration(t *testing.T) {
+	outputDir, err := ioutil.TempDir("", "")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	// Clean up
+	defer func() {
+		os.RemoveAll(outputDir)
+	}()
+
+	// The collection is named api.postman_collection.json when the configuration doesn't give an output
+	gen, err := NewGenerator(config.NewConfig(false, "", outputDir, "", nil, config.FormatPostman), testJSONData())
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.IsType(t, &Postman{}, gen)
+	assert.Nil(t, gen.Generate())
+
+	checks := map[string]string{
+		"testdata/postman/api.postman_collection.json":  filepath.Join(outputDir, PostmanDefaultOutput),
+		"testdata/postman/api.postman_environment.json": filepath.Join(outputDir, "api.postman_environment.json"),
+	}
+
+	for golden, file := range checks {
+		expected, err := testLoadFile(golden)
+		assert.Nil(t, err)
+
+		output, err := testLoadFile(file)
+		assert.Nil(t, err)
+
+		assert.Exactly(t, expected, output, golden)
+	}
+}
+
+func TestPostmanEnvironmentOutput(t *testing.T) {
+	t.Parallel()
+
+	assert.Exactly(t, "out/notes.postman_environment.json", PostmanEnvironmentOutput("out/notes.postman_collection.json"))
+	assert.Exactly(t, "out/notes.postman_environment.json", PostmanEnvironmentOutput("out/notes.json"))
+}
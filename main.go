@@ -2,21 +2,29 @@ package main
 
 import (
 	"os"
+	"strings"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/command"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/generator/config"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/parser"
 	"github.com/urfave/cli"
 )
 
 func main() {
-	cmd := &command.GenerateCommand{}
+	newApp(logrus.New()).Run(os.Args)
+}
 
-	logger := logrus.New()
+// newApp Returns the command line's application, the commands' failures are logged and exit with the status 1
+func newApp(logger *logrus.Logger) *cli.App {
+	cmd := &command.GenerateCommand{Logger: logger}
+	exportCmd := &command.ExportCommand{Logger: logger}
+	convertCmd := &command.ConvertCommand{Logger: logger}
 
 	app := cli.NewApp()
 	app.Name = "RubberDoc"
 	app.Version = "v0.1-alpha-2"
-	app.Description = "A documentation generator for RAML and Blueprint."
+	app.Description = "A documentation generator for RAML, Blueprint and OpenAPI."
 	app.Usage = ""
 
 	var debugLogging bool
@@ -41,7 +49,7 @@ func main() {
 	app.Commands = []cli.Command{
 		{
 			Name:  "generate",
-			Usage: "This command receives a configuration file and a specification file written in RAML or Blueprint.",
+			Usage: "This command receives a configuration file and a specification file written in RAML, Blueprint or OpenAPI.",
 
 			Flags: []cli.Flag{
 				cli.StringFlag{
@@ -56,14 +64,112 @@ func main() {
 					Usage:       "Specify the configuration's file location.",
 					Destination: &cmd.ConfigFile,
 				},
+				cli.StringFlag{
+					Name:        "format",
+					Value:       "",
+					Usage:       "Specify the Specification's format (" + strings.Join(parser.FormatNames(), ", ") + "), it's detected from the content by default.",
+					Destination: &cmd.Format,
+				},
+				cli.BoolFlag{
+					Name:        "strict",
+					Usage:       "Fail if the specification's parser reports warnings.",
+					Destination: &cmd.Strict,
+				},
+			},
+			Action: func(c *cli.Context) error {
+				return exitOnError(logger, cmd.Execute())
+			},
+		},
+		{
+			Name:  "export",
+			Usage: "This command exports a specification file written in RAML, Blueprint or OpenAPI as a versioned JSON document or a Postman collection.",
+
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:        "spec",
+					Value:       "",
+					Usage:       "Specify the Specification's file location.",
+					Destination: &exportCmd.SpecFile,
+				},
+				cli.StringFlag{
+					Name:        "spec-format",
+					Value:       "",
+					Usage:       "Specify the Specification's format (" + strings.Join(parser.FormatNames(), ", ") + "), it's detected from the content by default.",
+					Destination: &exportCmd.SpecFormat,
+				},
+				cli.StringFlag{
+					Name:        "format",
+					Value:       config.FormatJSON,
+					Usage:       "Specify the export's format (" + config.FormatJSON + ", " + config.FormatPostman + ").",
+					Destination: &exportCmd.Format,
+				},
+				cli.StringFlag{
+					Name:        "output, o",
+					Value:       "",
+					Usage:       "Specify the export's file location, it's written to the standard output by default. The Postman environment is written next to the file.",
+					Destination: &exportCmd.Output,
+				},
+				cli.BoolFlag{
+					Name:        "strict",
+					Usage:       "Fail if the specification's parser reports warnings.",
+					Destination: &exportCmd.Strict,
+				},
 			},
-			Action: func(c *cli.Context) {
-				if err := cmd.Execute(); err != nil {
-					logger.Error(err)
-				}
+			Action: func(c *cli.Context) error {
+				return exitOnError(logger, exportCmd.Execute())
+			},
+		},
+		{
+			Name:  "convert",
+			Usage: "This command converts a specification file written in RAML, Blueprint or Swagger into an OpenAPI 3.0 document.",
+
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:        "spec",
+					Value:       "",
+					Usage:       "Specify the Specification's file location.",
+					Destination: &convertCmd.SpecFile,
+				},
+				cli.StringFlag{
+					Name:        "spec-format",
+					Value:       "",
+					Usage:       "Specify the Specification's format (" + strings.Join(parser.FormatNames(), ", ") + "), it's detected from the content by default.",
+					Destination: &convertCmd.SpecFormat,
+				},
+				cli.StringFlag{
+					Name:        "output, o",
+					Value:       "",
+					Usage:       "Specify the OpenAPI document's file location, it's written to the standard output by default.",
+					Destination: &convertCmd.Output,
+				},
+				cli.BoolFlag{
+					Name:        "json",
+					Usage:       "Write the OpenAPI document as JSON, it's written as YAML unless the output's extension is .json.",
+					Destination: &convertCmd.JSON,
+				},
+				cli.BoolFlag{
+					Name:        "strict",
+					Usage:       "Fail if the specification's parser reports warnings or if constructs can't be represented by OpenAPI.",
+					Destination: &convertCmd.Strict,
+				},
+			},
+			Action: func(c *cli.Context) error {
+				return exitOnError(logger, convertCmd.Execute())
 			},
 		},
 	}
 
-	app.Run(os.Args)
+	return app
+}
+
+// exitOnError Logs the command's error and returns the exit's error, so the failures (e.g. the strict mode's warnings) fail the scripts
+func exitOnError(logger logrus.FieldLogger, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	logger.Error(err)
+
+	// The error is already logged, the exit's message is empty so it isn't printed twice
+	return cli.NewExitError("", 1)
 }
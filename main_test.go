@@ -0,0 +1,46 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli"
+)
+
+func TestApp_Strict_ExitCode(t *testing.T) {
+	outputDir, err := ioutil.TempDir("", "")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	// Clean up
+	defer func() {
+		os.RemoveAll(outputDir)
+	}()
+
+	exitCode := 0
+	osExiter := cli.OsExiter
+	cli.OsExiter = func(code int) { exitCode = code }
+	defer func() {
+		cli.OsExiter = osExiter
+	}()
+
+	logger := logrus.New()
+	logger.Out = ioutil.Discard
+
+	args := []string{"rubberdoc", "export", "--spec", "parser/testdata/blueprint/warnings.apib", "--output", filepath.Join(outputDir, "api.json")}
+
+	// The warnings are only logged unless the strict mode is enabled
+	assert.Nil(t, newApp(logger).Run(args))
+	assert.Exactly(t, 0, exitCode)
+
+	err = newApp(logger).Run(append(args, "--strict"))
+	if assert.NotNil(t, err) {
+		assert.Exactly(t, 1, err.(cli.ExitCoder).ExitCode())
+	}
+	assert.Exactly(t, 1, exitCode)
+}
@@ -0,0 +1,124 @@
+// Package apib parses API Blueprint documents into API Elements (refract), the same structure produced by drafter.
+//
+// The package only depends on the standard library, so documents can be parsed without linking drafter through cgo.
+package apib
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Parse Parses an API Blueprint document and returns its API Elements' parse result
+func Parse(source []byte) (result map[string]interface{}, err error) {
+	p := newParser(string(source))
+
+	var bp *blueprint
+	if bp, err = p.parse(); err != nil {
+		return
+	}
+
+	result = bp.refract()
+
+	return
+}
+
+// ParseJSON Parses an API Blueprint document and returns its API Elements' parse result serialized as JSON
+func ParseJSON(source []byte) (out []byte, err error) {
+	var result map[string]interface{}
+	if result, err = Parse(source); err != nil {
+		return
+	}
+
+	return json.Marshal(result)
+}
+
+// blueprint Intermediate representation of the document, references are resolved while rendering the API Elements
+type blueprint struct {
+	metadata    []metadata
+	title       string
+	description string
+	// items keeps groups and resources declared outside groups in their order
+	items  []interface{}
+	models map[string]*payload
+	// dataStructures are the named types declared in the Data Structures sections
+	dataStructures []*mson
+	// warnings are rendered as annotations, located in the source as drafter does
+	warnings []warning
+}
+
+// warning Represents an issue found in the document which doesn't prevent it from being parsed
+type warning struct {
+	message string
+	offset  int
+	length  int
+}
+
+type metadata struct {
+	key   string
+	value string
+}
+
+type group struct {
+	title       string
+	description string
+	resources   []*resource
+}
+
+type resource struct {
+	title       string
+	description string
+	href        string
+	parameters  []*parameter
+	model       *payload
+	attributes  *mson
+	actions     []*action
+}
+
+type action struct {
+	title       string
+	description string
+	method      string
+	href        string
+	parameters  []*parameter
+	attributes  *mson
+	// payloads keeps requests and responses in their order, they are paired into transactions while rendering
+	payloads []*payload
+}
+
+type parameter struct {
+	name        string
+	example     string
+	typ         string
+	description string
+	def         string
+	required    bool
+	members     []string
+}
+
+const (
+	payloadModel    = "model"
+	payloadRequest  = "request"
+	payloadResponse = "response"
+)
+
+type payload struct {
+	kind        string
+	name        string
+	mediaType   string
+	description string
+	headers     []metadata
+	body        string
+	schema      string
+	attributes  *mson
+	reference   string
+}
+
+// hasHeader Checks if the payload declares the header given
+func (p *payload) hasHeader(name string) bool {
+	for _, h := range p.headers {
+		if strings.EqualFold(h.key, name) {
+			return true
+		}
+	}
+	return false
+}
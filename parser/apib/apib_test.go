@@ -0,0 +1,164 @@
+package apib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const document = `FORMAT: 1A
+HOST: https://api.example.com
+
+# Notes API
+Notes description.
+
+# Group Notes
+
+## Note [/notes/{id}]
+
++ Parameters
+    + id: 42 (number, optional) - The note's id
+        + Default: ` + "`1`" + `
+
++ Model (application/json)
+
+        {"id": 42}
+
+### Retrieve a Note [GET]
+
++ Response 200
+
+    [Note][]
+
+### Delete a Note [DELETE]
+
++ Response 204
+`
+
+func TestParse(t *testing.T) {
+	result, err := Parse([]byte(document))
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Exactly(t, "parseResult", result["element"])
+
+	api := result["content"].([]interface{})[0].(map[string]interface{})
+	assert.Exactly(t, "Notes API", api["meta"].(map[string]interface{})["title"])
+	assert.Len(t, api["attributes"].(map[string]interface{})["meta"], 2)
+
+	content := api["content"].([]interface{})
+	assert.Exactly(t, copyElement("Notes description."), content[0])
+
+	group := content[1].(map[string]interface{})
+	assert.Exactly(t, "Notes", group["meta"].(map[string]interface{})["title"])
+
+	resource := group["content"].([]interface{})[0].(map[string]interface{})
+	attributes := resource["attributes"].(map[string]interface{})
+	assert.Exactly(t, "/notes/{id}", attributes["href"])
+
+	id := attributes["hrefVariables"].(map[string]interface{})["content"].([]interface{})[0].(map[string]interface{})
+	assert.Exactly(t, map[string]interface{}{"description": "The note's id"}, id["meta"])
+	assert.Exactly(t, classes("optional"), id["attributes"].(map[string]interface{})["typeAttributes"])
+	assert.Exactly(t, element("number", nil, map[string]interface{}{"default": stringElement("1")}, "42"), id["content"].(map[string]interface{})["value"])
+
+	actions := resource["content"].([]interface{})
+	if !assert.Len(t, actions, 2) {
+		return
+	}
+
+	retrieve := actions[0].(map[string]interface{})["content"].([]interface{})[0].(map[string]interface{})["content"].([]interface{})
+	assert.Exactly(t, element("httpRequest", nil, map[string]interface{}{"method": "GET"}, nil), retrieve[0])
+
+	response := retrieve[1].(map[string]interface{})
+	assert.Exactly(t, "200", response["attributes"].(map[string]interface{})["statusCode"])
+	assert.Exactly(t, element("asset", map[string]interface{}{"classes": classes("messageBody")}, map[string]interface{}{"contentType": "application/json"}, "{\"id\": 42}\n"), response["content"].([]interface{})[0])
+
+	deletion := actions[1].(map[string]interface{})["content"].([]interface{})[0].(map[string]interface{})["content"].([]interface{})
+	assert.Exactly(t, "204", deletion[1].(map[string]interface{})["attributes"].(map[string]interface{})["statusCode"])
+}
+
+func TestParse_ActionOutsideResource(t *testing.T) {
+	_, err := Parse([]byte("# API\n\n## List Notes [GET]\n"))
+
+	assert.NotNil(t, err)
+}
+
+func TestParse_DataStructures(t *testing.T) {
+	result, err := Parse([]byte(`FORMAT: 1A
+
+# API
+
+# Data Structures
+
+## Note (Base)
+A note.
+
++ title: Buy milk (string, required) - The title
++ tags: home, work (array[string])
++ status (enum[string])
+    + Members
+        + open
+        + closed
+    + Default: open
++ Include Audited
+`))
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	api := result["content"].([]interface{})[0].(map[string]interface{})
+	category := api["content"].([]interface{})[0].(map[string]interface{})
+	assert.Exactly(t, classes("dataStructures"), category["meta"].(map[string]interface{})["classes"])
+
+	note := category["content"].([]interface{})[0].(map[string]interface{})["content"].([]interface{})[0].(map[string]interface{})
+	assert.Exactly(t, "Base", note["element"])
+	assert.Exactly(t, map[string]interface{}{"id": "Note", "description": "A note."}, note["meta"])
+
+	members := note["content"].([]interface{})
+	if !assert.Len(t, members, 4) {
+		return
+	}
+
+	assert.Exactly(t, element("ref", nil, map[string]interface{}{"path": "content"}, "Audited"), members[0])
+	assert.Exactly(t, element("member", map[string]interface{}{"description": "The title"}, map[string]interface{}{"typeAttributes": classes("required")}, map[string]interface{}{
+		"key":   stringElement("title"),
+		"value": stringElement("Buy milk"),
+	}), members[1])
+	assert.Exactly(t, memberElement("tags", element("array", nil, nil, []interface{}{stringElement("home"), stringElement("work")})), members[2])
+	assert.Exactly(t, memberElement("status", element("enum", nil, map[string]interface{}{
+		"default":      stringElement("open"),
+		"enumerations": element("array", nil, nil, []interface{}{stringElement("open"), stringElement("closed")}),
+	}, nil)), members[3])
+}
+
+func TestParse_Warnings(t *testing.T) {
+	result, err := Parse([]byte("FORMAT: 1A\n\n# Group Notes\n\n## Note [/notes/{id}]\n\n+ Parameters\n    + id (number)\n    + sort (string)\n"))
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	content := result["content"].([]interface{})
+	if !assert.Len(t, content, 3) {
+		return
+	}
+
+	assert.Exactly(t, element("annotation", map[string]interface{}{"classes": classes("warning")}, map[string]interface{}{
+		"sourceMap": []interface{}{element("sourceMap", nil, nil, []interface{}{[]interface{}{float64(12), float64(13)}})},
+	}, "expected the API's name, e.g. '# <API Name>'"), content[1])
+
+	assert.Exactly(t, "the parameter 'sort' isn't found within the URI template '/notes/{id}'", content[2].(map[string]interface{})["content"])
+}
+
+func TestParse_Format(t *testing.T) {
+	result, err := Parse([]byte("# API\n"))
+	if assert.Nil(t, err) {
+		content := result["content"].([]interface{})
+		assert.Exactly(t, "expected the API Blueprint's version, e.g. 'FORMAT: 1A'", content[1].(map[string]interface{})["content"])
+	}
+
+	_, err = Parse([]byte("HOST: https://example.com\nFORMAT: 2A\n\n# API\n"))
+	if assert.NotNil(t, err) {
+		assert.Exactly(t, `line 2: the FORMAT "2A" isn't supported, expected 1A`, err.Error())
+	}
+}
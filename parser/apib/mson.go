@@ -0,0 +1,365 @@
+package apib
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	msonHeadingRe     = regexp.MustCompile(`^#{1,6}\s+(.*?)\s*#*\s*$`)
+	msonDeclarationRe = regexp.MustCompile("^(`[^`]*`|[^:(]*?)\\s*(?::\\s*(`[^`]*`|[^(]*?))?\\s*(?:\\(([^)]*)\\))?\\s*(?:-\\s+(.*))?$")
+	msonNestedTypeRe  = regexp.MustCompile(`^(array|enum)\[([^\]]*)\]$`)
+	msonIncludeRe     = regexp.MustCompile(`^Include\s+(.+)$`)
+	msonSampleRe      = regexp.MustCompile(`^(Default|Sample)(?::\s*(.*))?$`)
+	msonGroupRe       = regexp.MustCompile(`^(Properties|Items|Members)$`)
+)
+
+// mson Represents a type or a member declared with MSON, e.g. + id: 42 (number, required) - The id
+type mson struct {
+	name  string
+	value string
+	typ   string
+	// nested is the type of the array's items or the enum's values, e.g. string for array[string]
+	nested      string
+	description string
+	required    bool
+	def         string
+	sample      string
+	// members are the object's members, the array's items or the enum's values
+	members  []*mson
+	includes []string
+	// named types are declared by the data structures and the resources' attributes
+	named bool
+}
+
+// parseDataStructures Parses the named types declared in the Data Structures section, one per heading
+func parseDataStructures(lines []string) (types []*mson) {
+	var current []string
+
+	flush := func(heading string) {
+		if heading == "" {
+			return
+		}
+
+		t := parseDeclaration(heading, false)
+		t.named = true
+		msonContent(t, current)
+		if t.typ == "" {
+			t.typ = "object"
+		}
+
+		types = append(types, t)
+	}
+
+	heading := ""
+	for _, line := range lines {
+		if m := msonHeadingRe.FindStringSubmatch(line); m != nil {
+			flush(heading)
+			heading, current = m[1], nil
+			continue
+		}
+
+		current = append(current, line)
+	}
+
+	flush(heading)
+
+	return
+}
+
+// parseAttributes Parses the attributes section, the type is declared in its header, e.g. + Attributes (object)
+func parseAttributes(name string, s *section) *mson {
+	attributes := parseDeclaration(s.header, false)
+	attributes.name = name
+	attributes.named = name != ""
+
+	msonContent(attributes, s.lines)
+	if attributes.typ == "" {
+		attributes.typ = "object"
+	}
+
+	return attributes
+}
+
+// parseDeclaration Parses the MSON's declaration: name: value (type, attributes) - description.
+// The values of arrays and enums aren't named, so the name is taken as their value
+func parseDeclaration(declaration string, isValue bool) *mson {
+	m := &mson{}
+
+	match := msonDeclarationRe.FindStringSubmatch(strings.TrimSpace(declaration))
+	if match == nil {
+		m.name = strings.TrimSpace(declaration)
+		return m
+	}
+
+	m.name = unquote(match[1])
+	m.value = unquote(match[2])
+	m.description = strings.TrimSpace(match[4])
+
+	if isValue {
+		m.name, m.value = "", m.name
+	}
+
+	var isDefault, isSample bool
+
+	for _, attr := range strings.Split(match[3], ",") {
+		switch attr = strings.TrimSpace(attr); attr {
+		case "":
+		case "required":
+			m.required = true
+		case "optional", "fixed", "fixed-type", "nullable":
+		case "default":
+			isDefault = true
+		case "sample":
+			isSample = true
+		default:
+			if n := msonNestedTypeRe.FindStringSubmatch(attr); n != nil {
+				m.typ, m.nested = n[1], strings.TrimSpace(n[2])
+			} else {
+				m.typ = attr
+			}
+		}
+	}
+
+	if isDefault {
+		m.def, m.value = m.value, ""
+	}
+
+	if isSample {
+		m.sample, m.value = m.value, ""
+	}
+
+	return m
+}
+
+// msonContent Parses the nested lines of the type or member: its description, members, defaults and samples
+func msonContent(m *mson, lines []string) {
+	var (
+		description []string
+		header      string
+		children    []string
+		inItem      bool
+	)
+
+	flush := func() {
+		if inItem {
+			msonItem(m, header, children)
+		}
+		header, children, inItem = "", nil, false
+	}
+
+	for _, line := range lines {
+		if indentOf(line) == 0 {
+			if item := listItemRe.FindStringSubmatch(line); item != nil {
+				flush()
+				header, inItem = strings.TrimSpace(item[1]), true
+				continue
+			}
+		}
+
+		if inItem {
+			children = append(children, dedent(line, 4))
+		} else {
+			description = append(description, line)
+		}
+	}
+
+	flush()
+
+	if desc := text(description); desc != "" {
+		if m.description != "" {
+			m.description += "\n"
+		}
+		m.description += desc
+	}
+}
+
+// msonItem Parses a list item nested in the type or member, it's either a keyword (Include, Default, Members ...) or a member
+func msonItem(m *mson, header string, children []string) {
+	if include := msonIncludeRe.FindStringSubmatch(header); include != nil {
+		m.includes = append(m.includes, unquote(include[1]))
+		return
+	}
+
+	if msonGroupRe.MatchString(header) {
+		msonContent(m, children)
+		return
+	}
+
+	if header == "One Of" {
+		options := &mson{}
+		msonContent(options, children)
+
+		// Only one of the options can be given, so none of them is required
+		for _, option := range options.members {
+			option.required = false
+		}
+		m.members = append(m.members, options.members...)
+		return
+	}
+
+	if sample := msonSampleRe.FindStringSubmatch(header); sample != nil {
+		value := unquote(sample[2])
+		if value == "" {
+			value = text(children)
+		}
+
+		if sample[1] == "Default" {
+			m.def = value
+		} else {
+			m.sample = value
+		}
+		return
+	}
+
+	member := parseDeclaration(header, m.typ == "array" || m.typ == "enum")
+	msonContent(member, children)
+
+	if member.typ == "" {
+		member.typ = m.nested
+	}
+
+	if member.typ == "" {
+		member.typ = "string"
+		if len(member.members) > 0 || len(member.includes) > 0 {
+			member.typ = "object"
+		}
+	}
+
+	// The array's values can be listed inline, e.g. + tags: home, work (array[string])
+	if member.typ == "array" && len(member.members) == 0 && member.value != "" {
+		for _, value := range strings.Split(member.value, ",") {
+			item := &mson{value: strings.TrimSpace(value), typ: member.nested}
+			if item.typ == "" {
+				item.typ = "string"
+			}
+			member.members = append(member.members, item)
+		}
+		member.value = ""
+	}
+
+	m.members = append(m.members, member)
+}
+
+// dataStructure Renders the data structure holding the type
+func (m *mson) dataStructure() map[string]interface{} {
+	return element("dataStructure", nil, nil, []interface{}{m.refract()})
+}
+
+// refract Renders the MSON's value element, named types are identified by their names
+func (m *mson) refract() map[string]interface{} {
+	meta := make(map[string]interface{})
+	attributes := make(map[string]interface{})
+
+	var content interface{}
+
+	// Members are described by the member's element
+	if m.named {
+		meta["id"] = m.name
+		if m.description != "" {
+			meta["description"] = m.description
+		}
+	}
+
+	switch m.typ {
+	case "array":
+		var items []interface{}
+		for _, item := range m.members {
+			items = append(items, item.refract())
+		}
+
+		if len(items) == 0 && m.nested != "" {
+			items = append(items, element(m.nested, nil, nil, nil))
+		}
+
+		content = items
+	case "enum":
+		var enumerations []interface{}
+		for _, value := range m.members {
+			enumerations = append(enumerations, value.refract())
+		}
+
+		if len(enumerations) > 0 {
+			attributes["enumerations"] = element("array", nil, nil, enumerations)
+		}
+
+		if m.value != "" {
+			content = element(m.valueType(), nil, nil, scalar(m.valueType(), m.value))
+		}
+	case "string", "number", "boolean":
+		if m.value != "" {
+			content = scalar(m.typ, m.value)
+		}
+	default:
+		// Objects and named types
+		var members []interface{}
+		for _, include := range m.includes {
+			members = append(members, element("ref", nil, map[string]interface{}{"path": "content"}, include))
+		}
+
+		for _, member := range m.members {
+			members = append(members, member.member())
+		}
+
+		content = members
+	}
+
+	if m.def != "" {
+		attributes["default"] = element(m.valueType(), nil, nil, scalar(m.valueType(), m.def))
+	}
+
+	if m.sample != "" {
+		attributes["samples"] = element("array", nil, nil, []interface{}{
+			element(m.valueType(), nil, nil, scalar(m.valueType(), m.sample)),
+		})
+	}
+
+	return element(m.typ, meta, attributes, content)
+}
+
+// member Renders the object's member with its key and value
+func (m *mson) member() map[string]interface{} {
+	var meta map[string]interface{}
+	if m.description != "" {
+		meta = map[string]interface{}{"description": m.description}
+	}
+
+	var attributes map[string]interface{}
+	if m.required {
+		attributes = map[string]interface{}{"typeAttributes": classes("required")}
+	}
+
+	return element("member", meta, attributes, map[string]interface{}{
+		"key":   stringElement(m.name),
+		"value": m.refract(),
+	})
+}
+
+// valueType Returns the type of the MSON's values, enums' values are typed by the enum's nested type
+func (m *mson) valueType() string {
+	if m.typ == "enum" {
+		if m.nested != "" {
+			return m.nested
+		}
+		return "string"
+	}
+
+	return m.typ
+}
+
+// scalar Converts the value to the JSON's type of the MSON's type given, values which can't be converted are kept as strings
+func scalar(typ, value string) interface{} {
+	switch typ {
+	case "number":
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,637 @@
+package apib
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// methods HTTP's methods recognized on action's headings
+const methods = `GET|POST|PUT|PATCH|DELETE|OPTIONS|HEAD|TRACE|CONNECT|LINK|UNLINK`
+
+var (
+	headingRe        = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	metadataRe       = regexp.MustCompile(`^([A-Za-z][\w-]*)\s*:\s*(.*)$`)
+	groupRe          = regexp.MustCompile(`^Group\s+(.+)$`)
+	dataStructuresRe = regexp.MustCompile(`^Data Structures$`)
+	namedActionRe    = regexp.MustCompile(`^(.*?)\s*\[(` + methods + `)(?:\s+(\S+))?\]$`)
+	namedResourceRe  = regexp.MustCompile(`^(.*?)\s*\[([/{]\S*)\]$`)
+	resourceActionRe = regexp.MustCompile(`^(` + methods + `)\s+([/{]\S*)$`)
+	actionRe         = regexp.MustCompile(`^(` + methods + `)$`)
+	resourceRe       = regexp.MustCompile(`^([/{]\S*)$`)
+	listItemRe       = regexp.MustCompile(`^[+*-]\s+(.*)$`)
+	sectionRe        = regexp.MustCompile(`^(Parameters|Model|Request|Response|Attributes|Headers|Body|Schema|Relation)\b:?\s*(.*)$`)
+	signatureRe      = regexp.MustCompile(`^(.*?)\s*(?:\(([^)]*)\))?$`)
+	referenceRe      = regexp.MustCompile(`^\[([^\]]+)\]\[\]$`)
+	parameterRe      = regexp.MustCompile("^(`[^`]+`|[^\\s:(]+)\\s*(?::\\s*(`[^`]*`|[^\\s(]+))?\\s*(?:\\(([^)]*)\\))?\\s*(?:-\\s*(.*))?$")
+	defaultRe        = regexp.MustCompile(`^Default:\s*(.*)$`)
+	uriTemplateRe    = regexp.MustCompile(`\{([^}]*)\}`)
+)
+
+const (
+	blockAPI = iota
+	blockGroup
+	blockDataStructures
+	blockResource
+	blockAction
+)
+
+// block Represents a heading recognized by the API Blueprint's grammar and the lines following it
+type block struct {
+	kind   int
+	line   int
+	title  string
+	method string
+	href   string
+	lines  []string
+}
+
+// section Represents a list item section (+ Request, + Parameters ...) and its content, unindented one level
+type section struct {
+	keyword string
+	header  string
+	lines   []string
+}
+
+type parser struct {
+	lines []string
+	// offsets are the positions in the original source where the lines start
+	offsets []int
+}
+
+func newParser(source string) *parser {
+	offsets := []int{0}
+	for i, c := range source {
+		if c == '\n' {
+			offsets = append(offsets, i+1)
+		}
+	}
+
+	source = strings.TrimPrefix(source, "\ufeff")
+	source = strings.Replace(source, "\r\n", "\n", -1)
+	source = strings.Replace(source, "\t", "    ", -1)
+
+	return &parser{lines: strings.Split(source, "\n"), offsets: offsets}
+}
+
+// warn Reports the issue found at the line given, starting at 1
+func (p *parser) warn(bp *blueprint, line int, format string, args ...interface{}) {
+	w := warning{message: fmt.Sprintf(format, args...)}
+
+	if line > 0 && line <= len(p.offsets) {
+		w.offset = p.offsets[line-1]
+		w.length = len(p.lines[line-1])
+	}
+
+	bp.warnings = append(bp.warnings, w)
+}
+
+// parse Builds the intermediate representation of the document
+func (p *parser) parse() (bp *blueprint, err error) {
+	bp = &blueprint{models: make(map[string]*payload)}
+
+	start := p.metadata(bp)
+	// The metadata are declared on consecutive lines, before the content's start
+	if err = p.checkFormat(bp, start-len(bp.metadata)); err != nil {
+		return
+	}
+
+	blocks, intro := p.blocks(start)
+
+	bp.description = text(intro)
+
+	if len(blocks) == 0 || blocks[0].kind != blockAPI {
+		line := start
+		for line < len(p.lines)-1 && strings.TrimSpace(p.lines[line]) == "" {
+			line++
+		}
+		p.warn(bp, line+1, "expected the API's name, e.g. '# <API Name>'")
+	}
+
+	var (
+		currentGroup    *group
+		currentResource *resource
+	)
+
+	for _, b := range blocks {
+		switch b.kind {
+		case blockAPI:
+			bp.title = b.title
+			bp.description = text(b.lines)
+		case blockGroup:
+			currentGroup = &group{title: b.title, description: text(b.lines)}
+			currentResource = nil
+			bp.items = append(bp.items, currentGroup)
+		case blockDataStructures:
+			// Data structures are not bound to the resources declared before them
+			currentGroup = nil
+			currentResource = nil
+			bp.dataStructures = append(bp.dataStructures, parseDataStructures(b.lines)...)
+		case blockResource:
+			currentResource = &resource{title: b.title, href: b.href}
+
+			if b.method != "" {
+				// Shorthand resource's heading (## GET /uri), its content belongs to the action
+				a := &action{method: b.method}
+				p.actionContent(a, currentResource, b.lines)
+				currentResource.actions = append(currentResource.actions, a)
+			} else {
+				p.resourceContent(currentResource, b.lines, bp.models)
+			}
+
+			p.checkParameters(bp, b.line, currentResource.href, currentResource.parameters)
+
+			if currentGroup != nil {
+				currentGroup.resources = append(currentGroup.resources, currentResource)
+			} else {
+				bp.items = append(bp.items, currentResource)
+			}
+		case blockAction:
+			if currentResource == nil {
+				err = fmt.Errorf("line %d: the action %q isn't declared within a resource", b.line, b.title)
+				return
+			}
+
+			a := &action{title: b.title, method: b.method, href: b.href}
+			p.actionContent(a, nil, b.lines)
+			currentResource.actions = append(currentResource.actions, a)
+
+			href := a.href
+			if href == "" {
+				href = currentResource.href
+			}
+			p.checkParameters(bp, b.line, href, a.parameters)
+		}
+	}
+
+	return
+}
+
+// metadata Reads the metadata declared at the beginning of the document, returning the line where the content starts
+func (p *parser) metadata(bp *blueprint) int {
+	i := 0
+	for i < len(p.lines) && strings.TrimSpace(p.lines[i]) == "" {
+		i++
+	}
+
+	for ; i < len(p.lines); i++ {
+		m := metadataRe.FindStringSubmatch(p.lines[i])
+		if m == nil {
+			break
+		}
+
+		bp.metadata = append(bp.metadata, metadata{key: m[1], value: strings.TrimSpace(m[2])})
+	}
+
+	return i
+}
+
+// checkFormat Validates the FORMAT metadata, only the 1A's version is supported. Its absence is reported as a warning
+func (p *parser) checkFormat(bp *blueprint, first int) error {
+	for i, m := range bp.metadata {
+		if m.key != "FORMAT" {
+			continue
+		}
+
+		if m.value != "1A" {
+			return fmt.Errorf("line %d: the FORMAT %q isn't supported, expected 1A", first+i+1, m.value)
+		}
+		return nil
+	}
+
+	p.warn(bp, 1, "expected the API Blueprint's version, e.g. 'FORMAT: 1A'")
+
+	return nil
+}
+
+// blocks Splits the document's lines by the headings recognized, the lines before the first heading are returned apart
+func (p *parser) blocks(start int) (blocks []*block, intro []string) {
+	var (
+		current *block
+		fence   string
+	)
+
+	for i := start; i < len(p.lines); i++ {
+		line := p.lines[i]
+
+		// Headings within fenced code are part of the content
+		inFence := fence != "" || isFence(line)
+		fence = nextFence(fence, line)
+
+		if m := headingRe.FindStringSubmatch(line); m != nil && !inFence {
+			if b := classify(m[2], len(blocks) == 0); b != nil {
+				b.line = i + 1
+				blocks = append(blocks, b)
+				current = b
+				continue
+			}
+		}
+
+		if current != nil {
+			current.lines = append(current.lines, line)
+		} else {
+			intro = append(intro, line)
+		}
+	}
+
+	return
+}
+
+// classify Returns the block represented by the heading, nil is returned for headings which are part of descriptions
+func classify(heading string, first bool) *block {
+	if m := groupRe.FindStringSubmatch(heading); m != nil {
+		return &block{kind: blockGroup, title: m[1]}
+	}
+
+	if dataStructuresRe.MatchString(heading) {
+		return &block{kind: blockDataStructures, title: heading}
+	}
+
+	if m := namedActionRe.FindStringSubmatch(heading); m != nil {
+		return &block{kind: blockAction, title: m[1], method: m[2], href: m[3]}
+	}
+
+	if m := namedResourceRe.FindStringSubmatch(heading); m != nil {
+		return &block{kind: blockResource, title: m[1], href: m[2]}
+	}
+
+	if m := resourceActionRe.FindStringSubmatch(heading); m != nil {
+		return &block{kind: blockResource, method: m[1], href: m[2]}
+	}
+
+	if m := actionRe.FindStringSubmatch(heading); m != nil {
+		return &block{kind: blockAction, method: m[1]}
+	}
+
+	if m := resourceRe.FindStringSubmatch(heading); m != nil {
+		return &block{kind: blockResource, href: m[1]}
+	}
+
+	// The first heading, if not recognized, names the API
+	if first {
+		return &block{kind: blockAPI, title: heading}
+	}
+
+	return nil
+}
+
+// checkParameters Warns about the parameters which aren't variables of the URI template they describe
+func (p *parser) checkParameters(bp *blueprint, line int, href string, params []*parameter) {
+	variables := uriVariables(href)
+
+	for _, param := range params {
+		if !variables[param.name] {
+			p.warn(bp, line, "the parameter '%s' isn't found within the URI template '%s'", param.name, href)
+		}
+	}
+}
+
+// resourceContent Parses the resource's description, parameters and model
+func (p *parser) resourceContent(r *resource, lines []string, models map[string]*payload) {
+	description, sections := splitSections(lines)
+	r.description = text(description)
+
+	for _, s := range sections {
+		switch s.keyword {
+		case "Parameters":
+			r.parameters = append(r.parameters, parseParameters(s.lines)...)
+		case "Attributes":
+			r.attributes = parseAttributes(r.title, s)
+		case "Model":
+			r.model = parsePayload(payloadModel, s)
+
+			// Models are referenced by the resource's name unless a name is given
+			name := r.model.name
+			if name == "" {
+				name = r.title
+			}
+			models[name] = r.model
+		}
+	}
+}
+
+// actionContent Parses the action's description, parameters and payloads. Parameters of shorthand actions belong to the resource
+func (p *parser) actionContent(a *action, r *resource, lines []string) {
+	description, sections := splitSections(lines)
+	a.description = text(description)
+
+	for _, s := range sections {
+		switch s.keyword {
+		case "Parameters":
+			if r != nil {
+				r.parameters = append(r.parameters, parseParameters(s.lines)...)
+			} else {
+				a.parameters = append(a.parameters, parseParameters(s.lines)...)
+			}
+		case "Attributes":
+			a.attributes = parseAttributes("", s)
+		case "Request":
+			a.payloads = append(a.payloads, parsePayload(payloadRequest, s))
+		case "Response":
+			a.payloads = append(a.payloads, parsePayload(payloadResponse, s))
+		}
+	}
+}
+
+// splitSections Splits the lines in the description and the sections declared after it
+func splitSections(lines []string) (description []string, sections []*section) {
+	var (
+		current *section
+		fence   string
+	)
+
+	for _, line := range lines {
+		if current == nil {
+			if fence != "" || isFence(line) {
+				fence = nextFence(fence, line)
+				description = append(description, line)
+				continue
+			}
+		}
+
+		if indentOf(line) == 0 {
+			if m := listItemRe.FindStringSubmatch(line); m != nil {
+				if s := sectionRe.FindStringSubmatch(strings.TrimSpace(m[1])); s != nil {
+					current = &section{keyword: s[1], header: strings.TrimSpace(s[2])}
+					sections = append(sections, current)
+					continue
+				} else if current != nil {
+					// Unknown sections are skipped along with their content
+					current = &section{}
+					continue
+				}
+			}
+		}
+
+		if current != nil {
+			current.lines = append(current.lines, dedent(line, 4))
+		} else {
+			description = append(description, line)
+		}
+	}
+
+	return
+}
+
+// parsePayload Parses a model, request or response section
+func parsePayload(kind string, s *section) *payload {
+	pl := &payload{kind: kind}
+
+	if m := signatureRe.FindStringSubmatch(s.header); m != nil {
+		pl.name = strings.TrimSpace(m[1])
+		pl.mediaType = strings.TrimSpace(m[2])
+	}
+
+	if kind == payloadResponse && pl.name == "" {
+		pl.name = "200"
+	}
+
+	description, nested := splitSections(s.lines)
+
+	if len(nested) == 0 {
+		description, pl.body = extractCode(s.lines)
+	}
+
+	// A reference to a model replaces the payload's description
+	var desc []string
+	for _, line := range description {
+		if m := referenceRe.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			pl.reference = m[1]
+			continue
+		}
+		desc = append(desc, line)
+	}
+	pl.description = text(desc)
+
+	for _, n := range nested {
+		_, code := extractCode(n.lines)
+
+		switch n.keyword {
+		case "Headers":
+			if code == "" {
+				code = strings.Join(n.lines, "\n")
+			}
+			pl.headers = append(pl.headers, parseHeaders(code)...)
+		case "Body":
+			pl.body = code
+		case "Schema":
+			pl.schema = code
+		case "Attributes":
+			pl.attributes = parseAttributes("", n)
+		}
+	}
+
+	return pl
+}
+
+// parseHeaders Parses the lines of a headers section (Name: value)
+func parseHeaders(code string) (headers []metadata) {
+	for _, line := range strings.Split(code, "\n") {
+		if m := metadataRe.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			headers = append(headers, metadata{key: m[1], value: strings.TrimSpace(m[2])})
+		}
+	}
+	return
+}
+
+// parseParameters Parses the parameters section, including their default values and members
+func parseParameters(lines []string) (params []*parameter) {
+	var (
+		current   *parameter
+		inMembers bool
+	)
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		item := listItemRe.FindStringSubmatch(trimmed)
+
+		if indentOf(line) == 0 && item != nil {
+			current = newParameter(item[1])
+			inMembers = false
+			if current != nil {
+				params = append(params, current)
+			}
+			continue
+		}
+
+		if current == nil || trimmed == "" {
+			continue
+		}
+
+		if item != nil {
+			switch m := defaultRe.FindStringSubmatch(item[1]); {
+			case m != nil:
+				current.def = unquote(m[1])
+			case strings.HasPrefix(item[1], "Members"):
+				inMembers = true
+			case inMembers:
+				current.members = append(current.members, unquote(strings.SplitN(item[1], " - ", 2)[0]))
+			}
+			continue
+		}
+
+		if current.description != "" {
+			current.description += "\n"
+		}
+		current.description += trimmed
+	}
+
+	return
+}
+
+// newParameter Creates a parameter from its declaration: name: `example` (type, required) - description
+func newParameter(declaration string) *parameter {
+	m := parameterRe.FindStringSubmatch(strings.TrimSpace(declaration))
+	if m == nil {
+		return nil
+	}
+
+	param := &parameter{
+		name:        unquote(m[1]),
+		example:     unquote(m[2]),
+		description: strings.TrimSpace(m[4]),
+		typ:         "string",
+		required:    true,
+	}
+
+	for _, attr := range strings.Split(m[3], ",") {
+		switch attr = strings.TrimSpace(attr); {
+		case attr == "":
+		case attr == "required":
+			param.required = true
+		case attr == "optional":
+			param.required = false
+		case strings.HasPrefix(attr, "enum"):
+			param.typ = "enum"
+		default:
+			param.typ = attr
+		}
+	}
+
+	return param
+}
+
+// extractCode Splits the lines in the text and the first code block (indented or fenced) found
+func extractCode(lines []string) (description []string, code string) {
+	var (
+		block []string
+		found bool
+	)
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		if !found && isFence(line) {
+			found = true
+			fence := trimmed[:3]
+			indent := indentOf(line)
+
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+				block = append(block, dedent(lines[i], indent))
+			}
+			continue
+		}
+
+		if !found && trimmed != "" && indentOf(line) >= 4 {
+			found = true
+
+			for ; i < len(lines) && (indentOf(lines[i]) >= 4 || strings.TrimSpace(lines[i]) == ""); i++ {
+				block = append(block, dedent(lines[i], 4))
+			}
+			i--
+			continue
+		}
+
+		description = append(description, line)
+	}
+
+	// Trailing empty lines aren't part of the code
+	for len(block) > 0 && strings.TrimSpace(block[len(block)-1]) == "" {
+		block = block[:len(block)-1]
+	}
+
+	if len(block) > 0 {
+		code = strings.Join(block, "\n") + "\n"
+	}
+
+	return
+}
+
+//generic helper methods
+
+// text Joins the lines removing the leading and trailing empty lines
+func text(lines []string) string {
+	start, end := 0, len(lines)
+
+	for start < end && strings.TrimSpace(lines[start]) == "" {
+		start++
+	}
+
+	for end > start && strings.TrimSpace(lines[end-1]) == "" {
+		end--
+	}
+
+	return strings.TrimRight(strings.Join(lines[start:end], "\n"), " ")
+}
+
+// indentOf Returns the number of leading spaces, empty lines have no indentation
+func indentOf(line string) int {
+	if strings.TrimSpace(line) == "" {
+		return 0
+	}
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// dedent Removes up to n leading spaces from the line
+func dedent(line string, n int) string {
+	i := 0
+	for i < n && i < len(line) && line[i] == ' ' {
+		i++
+	}
+	return line[i:]
+}
+
+// isFence Checks if the line opens or closes a fenced code block
+func isFence(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")
+}
+
+// nextFence Returns the fence opened after the line given, an empty string means the line is outside of fenced code
+func nextFence(fence, line string) string {
+	if !isFence(line) {
+		return fence
+	}
+
+	trimmed := strings.TrimSpace(line)
+	if fence == "" {
+		return trimmed[:3]
+	}
+
+	if strings.HasPrefix(trimmed, fence) {
+		return ""
+	}
+
+	return fence
+}
+
+// uriVariables Returns the variables of the URI template, e.g. id and sort for /notes/{id}{?sort}
+func uriVariables(href string) map[string]bool {
+	variables := make(map[string]bool)
+
+	for _, m := range uriTemplateRe.FindAllStringSubmatch(href, -1) {
+		for _, name := range strings.Split(strings.TrimLeft(m[1], "+#./;?&"), ",") {
+			// Explode (*) and prefix (:n) modifiers aren't part of the name
+			name = strings.TrimSuffix(strings.SplitN(strings.TrimSpace(name), ":", 2)[0], "*")
+			variables[name] = true
+		}
+	}
+
+	return variables
+}
+
+// unquote Removes the backticks around the value
+func unquote(s string) string {
+	return strings.Trim(strings.TrimSpace(s), "`")
+}
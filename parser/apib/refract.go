@@ -0,0 +1,368 @@
+package apib
+
+// refract Renders the API Elements' parse result of the document
+func (bp *blueprint) refract() map[string]interface{} {
+	var content []interface{}
+
+	if bp.description != "" {
+		content = append(content, copyElement(bp.description))
+	}
+
+	for _, item := range bp.items {
+		switch i := item.(type) {
+		case *group:
+			content = append(content, i.refract(bp.models))
+		case *resource:
+			content = append(content, i.refract(bp.models))
+		}
+	}
+
+	if len(bp.dataStructures) > 0 {
+		var dataStructures []interface{}
+		for _, ds := range bp.dataStructures {
+			dataStructures = append(dataStructures, ds.dataStructure())
+		}
+		content = append(content, element("category", map[string]interface{}{"classes": classes("dataStructures")}, nil, dataStructures))
+	}
+
+	var meta []interface{}
+	for _, m := range bp.metadata {
+		meta = append(meta, element("member", map[string]interface{}{"classes": classes("user")}, nil, map[string]interface{}{
+			"key":   stringElement(m.key),
+			"value": stringElement(m.value),
+		}))
+	}
+
+	var attributes map[string]interface{}
+	if len(meta) > 0 {
+		attributes = map[string]interface{}{"meta": meta}
+	}
+
+	api := element("category", map[string]interface{}{"classes": classes("api"), "title": bp.title}, attributes, content)
+
+	result := []interface{}{api}
+	for _, w := range bp.warnings {
+		result = append(result, w.refract())
+	}
+
+	return element("parseResult", nil, nil, result)
+}
+
+// refract Renders the warning's annotation, its source map ([offset, length]) locates the line where the issue was found
+func (w warning) refract() map[string]interface{} {
+	return element("annotation", map[string]interface{}{"classes": classes("warning")}, map[string]interface{}{
+		"sourceMap": []interface{}{
+			element("sourceMap", nil, nil, []interface{}{[]interface{}{float64(w.offset), float64(w.length)}}),
+		},
+	}, w.message)
+}
+
+// refract Renders the resource group's category
+func (g *group) refract(models map[string]*payload) map[string]interface{} {
+	var content []interface{}
+
+	if g.description != "" {
+		content = append(content, copyElement(g.description))
+	}
+
+	for _, r := range g.resources {
+		content = append(content, r.refract(models))
+	}
+
+	return element("category", map[string]interface{}{"classes": classes("resourceGroup"), "title": g.title}, nil, content)
+}
+
+// refract Renders the resource and its transitions
+func (r *resource) refract(models map[string]*payload) map[string]interface{} {
+	var content []interface{}
+
+	if r.description != "" {
+		content = append(content, copyElement(r.description))
+	}
+
+	if r.attributes != nil {
+		content = append(content, r.attributes.dataStructure())
+	}
+
+	for _, a := range r.actions {
+		content = append(content, a.refract(models))
+	}
+
+	return element("resource", titleMeta(r.title), hrefAttributes(r.href, r.parameters), content)
+}
+
+// refract Renders the action's transition, its requests and responses are paired into transactions
+func (a *action) refract(models map[string]*payload) map[string]interface{} {
+	var content []interface{}
+
+	if a.description != "" {
+		content = append(content, copyElement(a.description))
+	}
+
+	if a.attributes != nil {
+		content = append(content, a.attributes.dataStructure())
+	}
+
+	for _, t := range a.transactions() {
+		tx := []interface{}{requestElement(a.method, t[0].resolve(models))}
+
+		if t[1] != nil {
+			tx = append(tx, responseElement(t[1].resolve(models)))
+		}
+
+		content = append(content, element("httpTransaction", nil, nil, tx))
+	}
+
+	return element("transition", titleMeta(a.title), hrefAttributes(a.href, a.parameters), content)
+}
+
+// transactions Pairs the requests with the responses following them. Responses without requests are paired with an empty one
+func (a *action) transactions() (pairs [][2]*payload) {
+	var requests, responses []*payload
+
+	flush := func() {
+		if len(requests) == 0 {
+			requests = []*payload{nil}
+		}
+		if len(responses) == 0 {
+			responses = []*payload{nil}
+		}
+
+		for _, req := range requests {
+			for _, resp := range responses {
+				pairs = append(pairs, [2]*payload{req, resp})
+			}
+		}
+
+		requests, responses = nil, nil
+	}
+
+	for _, pl := range a.payloads {
+		if pl.kind == payloadRequest {
+			if len(responses) > 0 {
+				flush()
+			}
+			requests = append(requests, pl)
+		} else {
+			responses = append(responses, pl)
+		}
+	}
+
+	flush()
+
+	return
+}
+
+// resolve Returns the payload with the content of the model referenced, if any
+func (p *payload) resolve(models map[string]*payload) *payload {
+	if p == nil || p.reference == "" {
+		return p
+	}
+
+	model, ok := models[p.reference]
+	if !ok {
+		return p
+	}
+
+	resolved := *model
+	resolved.kind = p.kind
+	resolved.name = p.name
+	resolved.reference = ""
+
+	if p.mediaType != "" {
+		resolved.mediaType = p.mediaType
+	}
+
+	if p.description != "" {
+		resolved.description = p.description
+	}
+
+	resolved.headers = append(append([]metadata{}, model.headers...), p.headers...)
+
+	return &resolved
+}
+
+// requestElement Renders the http request, it's also rendered for transactions without request to carry the method
+func requestElement(method string, req *payload) map[string]interface{} {
+	attributes := map[string]interface{}{"method": method}
+
+	if req == nil {
+		return element("httpRequest", nil, attributes, nil)
+	}
+
+	if headers := headersElement(req); headers != nil {
+		attributes["headers"] = headers
+	}
+
+	return element("httpRequest", titleMeta(req.name), attributes, payloadContent(req))
+}
+
+// responseElement Renders the http response
+func responseElement(resp *payload) map[string]interface{} {
+	attributes := map[string]interface{}{"statusCode": resp.name}
+
+	if headers := headersElement(resp); headers != nil {
+		attributes["headers"] = headers
+	}
+
+	return element("httpResponse", nil, attributes, payloadContent(resp))
+}
+
+// payloadContent Renders the description and assets (body and schema) of the payload
+func payloadContent(pl *payload) (content []interface{}) {
+	if pl.description != "" {
+		content = append(content, copyElement(pl.description))
+	}
+
+	if pl.attributes != nil {
+		content = append(content, pl.attributes.dataStructure())
+	}
+
+	var attributes map[string]interface{}
+	if pl.mediaType != "" {
+		attributes = map[string]interface{}{"contentType": pl.mediaType}
+	}
+
+	if pl.body != "" {
+		content = append(content, element("asset", map[string]interface{}{"classes": classes("messageBody")}, attributes, pl.body))
+	}
+
+	if pl.schema != "" {
+		content = append(content, element("asset", map[string]interface{}{"classes": classes("messageBodySchema")}, attributes, pl.schema))
+	}
+
+	return
+}
+
+// headersElement Renders the payload's headers, the Content-Type is taken from the media type if not declared
+func headersElement(pl *payload) map[string]interface{} {
+	var content []interface{}
+
+	if pl.mediaType != "" && !pl.hasHeader("Content-Type") {
+		content = append(content, memberElement("Content-Type", stringElement(pl.mediaType)))
+	}
+
+	for _, h := range pl.headers {
+		content = append(content, memberElement(h.key, stringElement(h.value)))
+	}
+
+	if len(content) == 0 {
+		return nil
+	}
+
+	return element("httpHeaders", nil, nil, content)
+}
+
+// hrefAttributes Renders the href and its variables
+func hrefAttributes(href string, params []*parameter) map[string]interface{} {
+	attributes := make(map[string]interface{})
+
+	if href != "" {
+		attributes["href"] = href
+	}
+
+	if len(params) > 0 {
+		var content []interface{}
+		for _, p := range params {
+			content = append(content, p.refract())
+		}
+		attributes["hrefVariables"] = element("hrefVariables", nil, nil, content)
+	}
+
+	return attributes
+}
+
+// refract Renders the parameter as a member of the href variables
+func (p *parameter) refract() map[string]interface{} {
+	valueAttributes := make(map[string]interface{})
+
+	if p.def != "" {
+		valueAttributes["default"] = stringElement(p.def)
+	}
+
+	if len(p.members) > 0 {
+		var enumerations []interface{}
+		for _, m := range p.members {
+			enumerations = append(enumerations, stringElement(m))
+		}
+		valueAttributes["enumerations"] = element("array", nil, nil, enumerations)
+	}
+
+	var value interface{}
+	if p.example != "" {
+		value = p.example
+	}
+
+	var meta map[string]interface{}
+	if p.description != "" {
+		meta = map[string]interface{}{"description": p.description}
+	}
+
+	typeAttribute := "optional"
+	if p.required {
+		typeAttribute = "required"
+	}
+
+	return element("member", meta, map[string]interface{}{"typeAttributes": classes(typeAttribute)}, map[string]interface{}{
+		"key":   stringElement(p.name),
+		"value": element(p.typ, nil, valueAttributes, value),
+	})
+}
+
+//generic helper methods
+
+// element Creates an element, empty meta, attributes and content are omitted as drafter does
+func element(name string, meta, attributes map[string]interface{}, content interface{}) map[string]interface{} {
+	el := map[string]interface{}{"element": name}
+
+	if len(meta) > 0 {
+		el["meta"] = meta
+	}
+
+	if len(attributes) > 0 {
+		el["attributes"] = attributes
+	}
+
+	switch c := content.(type) {
+	case nil:
+	case []interface{}:
+		if len(c) > 0 {
+			el["content"] = c
+		}
+	default:
+		el["content"] = c
+	}
+
+	return el
+}
+
+func stringElement(s string) map[string]interface{} {
+	return element("string", nil, nil, s)
+}
+
+func copyElement(s string) map[string]interface{} {
+	return element("copy", nil, nil, s)
+}
+
+func memberElement(key string, value map[string]interface{}) map[string]interface{} {
+	return element("member", nil, nil, map[string]interface{}{
+		"key":   stringElement(key),
+		"value": value,
+	})
+}
+
+func titleMeta(title string) map[string]interface{} {
+	if title == "" {
+		return nil
+	}
+	return map[string]interface{}{"title": title}
+}
+
+// classes Returns the classes in the same structure decoded from drafter's JSON
+func classes(cs ...string) []interface{} {
+	s := make([]interface{}, len(cs))
+	for i, c := range cs {
+		s[i] = c
+	}
+	return s
+}
@@ -1,3 +1,6 @@
+//go:build drafter
+// +build drafter
+
 package parser
 
 /*
@@ -22,8 +25,10 @@ import (
 	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/parser/walker"
 )
 
-//BlueprintParser Concrete's parser definition
-type BlueprintParser struct{}
+//BlueprintParser Concrete's parser definition, the document is parsed by drafter (build tag: drafter)
+type BlueprintParser struct {
+	diagnostics Diagnostics
+}
 
 //NewBlueprintParser Creates a blueprint parser
 func NewBlueprintParser() Parser {
@@ -31,10 +36,12 @@ func NewBlueprintParser() Parser {
 }
 
 //Parse Concrete implementation of the Parser.Parse method
-func (bp BlueprintParser) Parse(filename string, tra transformer.Transformer) (def *definition.Api, err error) {
+func (bp *BlueprintParser) Parse(filename string, tra transformer.Transformer) (def *definition.Api, err error) {
 	var raw []byte
 	var data interface{}
 
+	bp.diagnostics = nil
+
 	if raw, err = ioutil.ReadFile(filename); err != nil {
 		return
 	}
@@ -46,21 +53,40 @@ func (bp BlueprintParser) Parse(filename string, tra transformer.Transformer) (d
 	result := &C.drafter_result{}
 	opts := C.drafter_parse_options{}
 
-	if errCode := int(C.drafter_parse_blueprint(source, &result, opts)); errCode != 0 {
-		err = fmt.Errorf("Drafter execution failed with code: %d", errCode)
+	// The result holds the annotations even if the execution failed
+	errCode := int(C.drafter_parse_blueprint(source, &result, opts))
+
+	if err = json.NewDecoder(bytes.NewReader(bp.serialize(result))).Decode(&data); err != nil {
+		return
+	}
+
+	el := walker.NewObjectWalker(data)
+	bp.diagnostics = annotations(&el, raw)
+
+	if errs := bp.diagnostics.Errors(); len(errs) > 0 {
+		err = errs
 		return
 	}
 
-	if err = json.NewDecoder(bytes.NewReader(bp.serialize(result))).Decode(&data); err == nil {
-		def, err = tra.Transform(walker.NewObjectWalker(data))
+	if errCode != 0 {
+		err = fmt.Errorf("Drafter execution failed with code: %d", errCode)
+		return
 	}
 
+	def, err = tra.Transform(el)
+
 	return
 }
 
-func (bp BlueprintParser) serialize(drafterResult *C.drafter_result) []byte {
+//Diagnostics Returns the warnings and errors reported by drafter while parsing the last document
+func (bp *BlueprintParser) Diagnostics() Diagnostics {
+	return bp.diagnostics
+}
+
+func (bp *BlueprintParser) serialize(drafterResult *C.drafter_result) []byte {
 
-	opts := C.drafter_serialize_options{sourcemap: false, format: C.DRAFTER_SERIALIZE_JSON}
+	// The source map locates the annotations in the document
+	opts := C.drafter_serialize_options{sourcemap: true, format: C.DRAFTER_SERIALIZE_JSON}
 
 	serializer := C.drafter_serialize(drafterResult, opts)
 
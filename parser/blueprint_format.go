@@ -0,0 +1,40 @@
+package parser
+
+import (
+	"bytes"
+	"regexp"
+
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/parser/transformer"
+)
+
+// blueprintMetadataRe Matches the metadata declared at the beginning of a blueprint
+var blueprintMetadataRe = regexp.MustCompile(`^([\w-]+):\s*(.*)$`)
+
+func init() {
+	// The blueprint's parser is chosen by the drafter build tag, both share the same format
+	RegisterFormat(Format{
+		Name:           "blueprint",
+		Extensions:     []string{".apib", ".md"},
+		Detect:         isBlueprint,
+		NewParser:      NewBlueprintParser,
+		NewTransformer: transformer.NewBlueprintTransformer,
+	})
+}
+
+//isBlueprint Checks if the content is a blueprint, which is identified by the FORMAT: 1A metadata
+func isBlueprint(content []byte) bool {
+	content = bytes.TrimPrefix(content, []byte("\ufeff"))
+
+	for _, line := range bytes.Split(bytes.TrimSpace(content), []byte("\n")) {
+		m := blueprintMetadataRe.FindSubmatch(bytes.TrimRight(line, "\r"))
+		if m == nil {
+			return false
+		}
+
+		if string(m[1]) == "FORMAT" {
+			return string(bytes.TrimSpace(m[2])) == "1A"
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,57 @@
+//go:build !drafter
+// +build !drafter
+
+package parser
+
+import (
+	"io/ioutil"
+
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/definition"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/parser/apib"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/parser/transformer"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/parser/walker"
+)
+
+//BlueprintParser Concrete's parser definition, the document is parsed natively without drafter
+type BlueprintParser struct {
+	diagnostics Diagnostics
+}
+
+//NewBlueprintParser Creates a blueprint parser
+func NewBlueprintParser() Parser {
+	return &BlueprintParser{}
+}
+
+//Parse Concrete implementation of the Parser.Parse method
+func (bp *BlueprintParser) Parse(filename string, tra transformer.Transformer) (def *definition.Api, err error) {
+	var (
+		raw  []byte
+		data map[string]interface{}
+	)
+
+	bp.diagnostics = nil
+
+	if raw, err = ioutil.ReadFile(filename); err != nil {
+		return
+	}
+
+	if data, err = apib.Parse(raw); err != nil {
+		return
+	}
+
+	el := walker.NewObjectWalker(data)
+
+	if bp.diagnostics = annotations(&el, raw); len(bp.diagnostics.Errors()) > 0 {
+		err = bp.diagnostics.Errors()
+		return
+	}
+
+	def, err = tra.Transform(el)
+
+	return
+}
+
+//Diagnostics Returns the warnings and errors reported while parsing the last document
+func (bp *BlueprintParser) Diagnostics() Diagnostics {
+	return bp.diagnostics
+}
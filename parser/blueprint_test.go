@@ -28,10 +28,119 @@ func TestBlueprintParser_Integration(t *testing.T) {
 	t.Run("Version", parserTest.assertVersion)
 	t.Run("BaseURI", parserTest.assertBaseURI)
 	t.Run("Protocols", parserTest.assertProtocols)
+	t.Run("Metadata", parserTest.assertMetadata)
+	t.Run("Documentation", parserTest.assertDocumentation)
 	t.Run("ResourceGroups", parserTest.assertResourceGroups)
 	t.Run("Resources", parserTest.assertResources)
 }
 
+func TestBlueprintParser_DataStructures(t *testing.T) {
+	def, err := NewBlueprintParser().Parse("testdata/blueprint/mson.apib", transformer.NewBlueprintTransformer())
+	if !assert.Nil(t, err, "Blueprint parsing failed") {
+		return
+	}
+
+	if !assert.Len(t, def.CustomTypes, 3) {
+		return
+	}
+
+	note := def.CustomTypes[0]
+	assert.Exactly(t, "Note", note.Name)
+	assert.Exactly(t, "A note.", note.Description)
+	assert.Exactly(t, definition.ObjectKind, note.Kind)
+	assert.Exactly(t, []string{"Base"}, note.Parents)
+	assert.Exactly(t, []definition.CustomTypeProperty{
+		{
+			Name:       "author",
+			Type:       "object",
+			Properties: []definition.CustomTypeProperty{{Name: "name", Type: "string", Example: "john"}},
+		},
+		{Name: "createdAt", Type: "string"},
+		{Name: "id", Type: "number"},
+		{Name: "status", Type: "string", Enum: []interface{}{"open", "closed"}, Default: "open"},
+		{Name: "title", Type: "string", Required: true, Description: "The title", Example: "Buy milk"},
+	}, note.Properties)
+
+	assert.Exactly(t, "Base", def.CustomTypes[1].Name)
+	assert.Exactly(t, "Audited", def.CustomTypes[2].Name)
+
+	tx := def.ResourceGroups[0].Resources[0].Actions[0].Transactions[0]
+
+	request := tx.Request.Body[0]
+	assert.Exactly(t, "Note", request.Type)
+	assert.Exactly(t, definition.MediaType("application/json"), request.MediaType)
+	if assert.NotNil(t, request.CustomType) {
+		assert.Exactly(t, []string{"Note"}, request.CustomType.Parents)
+		assert.Len(t, request.CustomType.Properties, 6)
+	}
+
+	response := tx.Response.Body[0]
+	if assert.NotNil(t, response.CustomType) {
+		assert.Exactly(t, []definition.CustomTypeProperty{
+			{Name: "id", Type: "number", Required: true, Description: "The note's id", Example: "42"},
+			{Name: "tags", Type: "string[]"},
+		}, response.CustomType.Properties)
+	}
+}
+
+func TestBlueprintParser_Payloads(t *testing.T) {
+	def, err := NewBlueprintParser().Parse("testdata/blueprint/payloads.apib", transformer.NewBlueprintTransformer())
+	if !assert.Nil(t, err, "Blueprint parsing failed") {
+		return
+	}
+
+	resource := def.ResourceGroups[0].Resources[0]
+	assert.Exactly(t, []definition.Parameter{
+		{Name: "sort", Description: "The sort order", Type: "string", Example: "", Enum: []interface{}{"asc", "desc"}, Default: "asc"},
+		{Name: "limit", Description: "The page size", Type: "number", Example: "10", Default: "20"},
+	}, resource.Href.Parameters)
+
+	list := resource.Actions[0].Transactions[0].Response.Body
+	if assert.Len(t, list, 1) && assert.NotNil(t, list[0].CustomType) {
+		assert.Exactly(t, "[{\"id\": 1, \"name\": \"john\"}]\n", list[0].Example)
+		assert.Exactly(t, "User[]", list[0].CustomType.Type)
+		assert.Exactly(t, definition.ArrayKind, list[0].CustomType.Kind)
+
+		if items := list[0].CustomType.Items; assert.NotNil(t, items) {
+			assert.Exactly(t, "User", items.Type)
+			assert.Exactly(t, definition.ObjectKind, items.Kind)
+			assert.Exactly(t, []definition.CustomTypeProperty{
+				{Name: "id", Type: "integer", Required: true, Description: "The user's id"},
+				{Name: "name", Type: "string"},
+			}, items.Properties)
+		}
+	}
+
+	create := resource.Actions[1].Transactions[0].Request.Body
+	if assert.Len(t, create, 1) && assert.NotNil(t, create[0].CustomType) {
+		assert.Exactly(t, definition.MediaType("application/json"), create[0].MediaType)
+		assert.Exactly(t, "A user", create[0].CustomType.Description)
+		assert.Exactly(t, []definition.CustomTypeProperty{{Name: "name", Type: "string", Required: true}}, create[0].CustomType.Properties)
+	}
+}
+
+func TestBlueprintParser_Ungrouped(t *testing.T) {
+	def, err := NewBlueprintParser().Parse("testdata/blueprint/ungrouped.apib", transformer.NewBlueprintTransformer())
+	if !assert.Nil(t, err, "Blueprint parsing failed") || !assert.Len(t, def.ResourceGroups, 2) {
+		return
+	}
+
+	// The resources outside groups are gathered in the implicit group, which is placed where the first one is declared
+	ungrouped := def.ResourceGroups[0]
+	assert.Exactly(t, "", ungrouped.Title)
+	if assert.Len(t, ungrouped.Resources, 1) {
+		assert.Exactly(t, "/notes", ungrouped.Resources[0].Href.Path)
+		assert.Exactly(t, "GET", ungrouped.Resources[0].Actions[0].Method)
+	}
+
+	users := def.ResourceGroups[1]
+	assert.Exactly(t, "Users", users.Title)
+	if assert.Len(t, users.Resources, 2) {
+		assert.Exactly(t, "/users", users.Resources[0].Href.Path)
+		assert.Exactly(t, "/tags", users.Resources[1].Href.Path)
+	}
+}
+
 func (bp *BlueprintParserTest) assertTitle(t *testing.T) {
 	t.Parallel()
 
@@ -56,6 +165,24 @@ func (bp *BlueprintParserTest) assertProtocols(t *testing.T) {
 	assert.Exactly(t, []definition.Protocol{definition.Protocol("https")}, bp.apiDef.Protocols)
 }
 
+func (bp *BlueprintParserTest) assertMetadata(t *testing.T) {
+	t.Parallel()
+
+	assert.Exactly(t, map[string]string{"format": "1A", "host": "https://alpha-api.app.net", "version": "1.0"}, bp.apiDef.Metadata)
+}
+
+func (bp *BlueprintParserTest) assertDocumentation(t *testing.T) {
+	t.Parallel()
+
+	assert.Exactly(t, []definition.Section{
+		{
+			Title: "Introduction",
+			Content: "This API Blueprint demonstrates a real world example documenting a portion of\n" +
+				"[App.net API](http://developers.app.net).\n\nNOTE: This document is a **work in progress**.",
+		},
+	}, bp.apiDef.Documentation)
+}
+
 func (bp *BlueprintParserTest) assertResourceGroups(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,149 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/parser/walker"
+)
+
+//Severity The severity of the diagnostic, errors prevent the document from being parsed
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+//Diagnostic Represents a warning or an error reported while parsing the document, located by the line and column it starts at.
+//The location is unknown (0) when the document's source isn't available
+type Diagnostic struct {
+	Severity Severity
+	Code     int
+	Message  string
+	Line     int
+	Column   int
+}
+
+//String Describes the diagnostic with its location, e.g. warning: line 3, column 1: message
+func (d Diagnostic) String() string {
+	if d.Line == 0 {
+		return fmt.Sprintf("%s: %s", d.Severity, d.Message)
+	}
+
+	return fmt.Sprintf("%s: line %d, column %d: %s", d.Severity, d.Line, d.Column, d.Message)
+}
+
+//Diagnostics Groups the diagnostics reported while parsing the document, they're an error when returned by the parser
+type Diagnostics []Diagnostic
+
+//Error Implements the error interface, describing every diagnostic
+func (ds Diagnostics) Error() string {
+	var messages []string
+	for _, d := range ds {
+		messages = append(messages, d.String())
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+//Errors Returns the diagnostics with the error's severity
+func (ds Diagnostics) Errors() Diagnostics {
+	return ds.filter(SeverityError)
+}
+
+//Warnings Returns the diagnostics with the warning's severity
+func (ds Diagnostics) Warnings() Diagnostics {
+	return ds.filter(SeverityWarning)
+}
+
+func (ds Diagnostics) filter(severity Severity) (filtered Diagnostics) {
+	for _, d := range ds {
+		if d.Severity == severity {
+			filtered = append(filtered, d)
+		}
+	}
+
+	return
+}
+
+//Diagnoser Implemented by the parsers which report diagnostics, the ones of the last document parsed
+type Diagnoser interface {
+	Diagnostics() Diagnostics
+}
+
+//annotations Extracts the annotations of the parse result (API Elements), located in the source if given
+func annotations(el *walker.ObjectWalker, source []byte) (ds Diagnostics) {
+	children, err := el.Path("content").Children()
+	if err != nil {
+		return
+	}
+
+	for _, child := range children {
+		if child.Path("element").String() != "annotation" {
+			continue
+		}
+
+		d := Diagnostic{
+			Severity: SeverityWarning,
+			Message:  child.Path("content").String(),
+		}
+
+		// The classes are either an array of strings or an array element of string elements, depending on the refract's version
+		classes := child.Path("meta.classes")
+		if _, ok := classes.Object().(map[string]interface{}); ok {
+			classes = classes.Path("content")
+		}
+
+		if cx, err := classes.Children(); err == nil {
+			for _, class := range cx {
+				if class.String() == "error" || class.Path("content").String() == "error" {
+					d.Severity = SeverityError
+				}
+			}
+		}
+
+		if code, ok := firstNumber(child.Path("attributes.code").Object()); ok {
+			d.Code = int(code)
+		}
+
+		if offset, ok := firstNumber(child.Path("attributes.sourceMap").Object()); ok && source != nil {
+			d.Line, d.Column = location(source, int(offset))
+		}
+
+		ds = append(ds, d)
+	}
+
+	return
+}
+
+//firstNumber Returns the first number held by the value, source maps are arrays of [offset, length] or number elements
+func firstNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case []interface{}:
+		for _, item := range v {
+			if n, ok := firstNumber(item); ok {
+				return n, true
+			}
+		}
+	case map[string]interface{}:
+		return firstNumber(v["content"])
+	}
+
+	return 0, false
+}
+
+//location Returns the line and column, starting at 1, of the offset in the source
+func location(source []byte, offset int) (line, column int) {
+	if offset > len(source) {
+		offset = len(source)
+	}
+
+	line = bytes.Count(source[:offset], []byte("\n")) + 1
+	column = offset - bytes.LastIndexByte(source[:offset], '\n')
+
+	return
+}
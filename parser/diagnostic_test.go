@@ -0,0 +1,67 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/parser/transformer"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/parser/walker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlueprintParser_Diagnostics(t *testing.T) {
+	p := NewBlueprintParser()
+
+	_, err := p.Parse("testdata/blueprint/warnings.apib", transformer.NewBlueprintTransformer())
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	diagnoser, ok := p.(Diagnoser)
+	if !assert.True(t, ok) {
+		return
+	}
+
+	assert.Exactly(t, Diagnostics{
+		{
+			Severity: SeverityWarning,
+			Message:  "the parameter 'sort' isn't found within the URI template '/notes{?page,limit}'",
+			Line:     7,
+			Column:   1,
+		},
+	}, diagnoser.Diagnostics())
+}
+
+func TestAnnotations(t *testing.T) {
+	source := []byte("FORMAT: 1A\n\n# API\n")
+
+	// Drafter's refract 1.0 wraps the source map's numbers in elements, the former one doesn't
+	data := map[string]interface{}{
+		"element": "parseResult",
+		"content": []interface{}{
+			map[string]interface{}{"element": "category"},
+			map[string]interface{}{
+				"element":    "annotation",
+				"meta":       map[string]interface{}{"classes": map[string]interface{}{"element": "array", "content": []interface{}{map[string]interface{}{"element": "string", "content": "error"}}}},
+				"attributes": map[string]interface{}{"code": map[string]interface{}{"element": "number", "content": float64(4)}, "sourceMap": map[string]interface{}{"element": "array", "content": []interface{}{map[string]interface{}{"element": "sourceMap", "content": []interface{}{map[string]interface{}{"element": "array", "content": []interface{}{map[string]interface{}{"element": "number", "content": float64(14)}, map[string]interface{}{"element": "number", "content": float64(3)}}}}}}}},
+				"content":    "unexpected name",
+			},
+			map[string]interface{}{
+				"element":    "annotation",
+				"meta":       map[string]interface{}{"classes": []interface{}{"warning"}},
+				"attributes": map[string]interface{}{"code": float64(6), "sourceMap": []interface{}{map[string]interface{}{"element": "sourceMap", "content": []interface{}{[]interface{}{float64(0), float64(10)}}}}},
+				"content":    "ignoring metadata",
+			},
+		},
+	}
+
+	el := walker.NewObjectWalker(data)
+	ds := annotations(&el, source)
+
+	assert.Exactly(t, Diagnostics{
+		{Severity: SeverityError, Code: 4, Message: "unexpected name", Line: 3, Column: 3},
+		{Severity: SeverityWarning, Code: 6, Message: "ignoring metadata", Line: 1, Column: 1},
+	}, ds)
+	assert.Len(t, ds.Errors(), 1)
+	assert.Len(t, ds.Warnings(), 1)
+	assert.Exactly(t, "error: line 3, column 3: unexpected name\nwarning: line 1, column 1: ignoring metadata", ds.Error())
+}
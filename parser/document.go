@@ -0,0 +1,76 @@
+package parser
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/gigforks/yaml"
+	"github.com/pkg/errors"
+)
+
+//readDocument Reads a YAML or JSON document and returns its normalized content
+func readDocument(filename string) (data interface{}, err error) {
+	var raw []byte
+
+	if raw, err = ioutil.ReadFile(filename); err != nil {
+		return
+	}
+
+	if data, err = decodeDocument(raw); err != nil {
+		err = errors.Wrapf(err, "Cannot parse the document %s", filename)
+	}
+
+	return
+}
+
+//decodeDocument Decodes a YAML or JSON content and returns its normalized content
+func decodeDocument(raw []byte) (data interface{}, err error) {
+	// JSON is a subset of YAML, so the same unmarshaller handles both
+	if err = yaml.Unmarshal(raw, &data); err != nil {
+		return
+	}
+
+	data = normalizeDocument(data)
+
+	return
+}
+
+//hasRootKey Checks if the YAML or JSON content given has the root key given
+func hasRootKey(raw []byte, key string) (ok bool) {
+	data, err := decodeDocument(raw)
+	if err != nil {
+		return
+	}
+
+	if root, isMap := data.(map[string]interface{}); isMap {
+		_, ok = root[key]
+	}
+
+	return
+}
+
+//normalizeDocument Converts the YAML's maps into the structure understood by the walker (map[string]interface{})
+func normalizeDocument(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			m[fmt.Sprint(k)] = normalizeDocument(item)
+		}
+		return m
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			m[k] = normalizeDocument(item)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(val))
+		for i, item := range val {
+			s[i] = normalizeDocument(item)
+		}
+		return s
+	}
+
+	return v
+}
@@ -0,0 +1,86 @@
+package parser
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/parser/transformer"
+)
+
+//Format Describes a specification's format, how it's detected and which parser and transformer handle it
+type Format struct {
+	Name string
+	// Extensions are only used to detect the format when no format recognizes the content
+	Extensions     []string
+	Detect         func(content []byte) bool
+	NewParser      func() Parser
+	NewTransformer func() transformer.Transformer
+}
+
+var formats = make(map[string]Format)
+
+//RegisterFormat Registers the format given, the parsers register their formats themselves
+func RegisterFormat(f Format) {
+	formats[f.Name] = f
+}
+
+//FormatNames Returns the names of the formats registered
+func FormatNames() (names []string) {
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return
+}
+
+//FormatByName Returns the format registered with the name given
+func FormatByName(name string) (f Format, err error) {
+	var ok bool
+	if f, ok = formats[strings.ToLower(name)]; !ok {
+		err = errors.Errorf("The format %s is unsupported, the formats supported are: %s", name, strings.Join(FormatNames(), ", "))
+	}
+
+	return
+}
+
+//DetectFormat Detects the format of the specification given by its content, the extension is used when the content isn't recognized
+func DetectFormat(filename string) (f Format, err error) {
+	var content []byte
+
+	if content, err = ioutil.ReadFile(filename); err != nil {
+		return
+	}
+
+	names := FormatNames()
+
+	for _, name := range names {
+		if formats[name].Detect != nil && formats[name].Detect(content) {
+			f = formats[name]
+			return
+		}
+	}
+
+	// The extension is only conclusive when a single format claims it
+	var candidates []string
+	ext := strings.ToLower(filepath.Ext(filename))
+	for _, name := range names {
+		for _, e := range formats[name].Extensions {
+			if e == ext {
+				candidates = append(candidates, name)
+			}
+		}
+	}
+
+	if len(candidates) != 1 {
+		err = errors.Errorf("The format of the specification %s cannot be detected, use one of the formats supported: %s", filename, strings.Join(names, ", "))
+		return
+	}
+
+	f = formats[candidates[0]]
+
+	return
+}
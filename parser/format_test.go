@@ -0,0 +1,84 @@
+package parser
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectFormat(t *testing.T) {
+	checks := []struct {
+		Filename string
+		Expected string
+	}{
+		{"testdata/raml/api.raml", "raml"},
+		{"testdata/blueprint/simple.apib", "blueprint"},
+		{"testdata/blueprint/simple.json", "refract"},
+		{"testdata/openapi/petstore.yaml", "openapi"},
+		{"testdata/swagger/petstore.yaml", "swagger"},
+	}
+
+	for _, check := range checks {
+		f, err := DetectFormat(check.Filename)
+
+		if assert.Nil(t, err, check.Filename) {
+			assert.Exactly(t, check.Expected, f.Name, check.Filename)
+		}
+	}
+}
+
+func TestDetectFormat_Content(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rubberdoc")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	checks := []struct {
+		Filename string
+		Content  string
+		Expected string
+	}{
+		{"api", "#%RAML 1.0\ntitle: API\n", "raml"},
+		{"api.md", "FORMAT: 1A\nHOST: https://api.example.com\n\n# API\n", "blueprint"},
+		{"api.txt", "HOST: https://api.example.com\nFORMAT: 1A\n\n# API\n", "blueprint"},
+		{"spec.yml", "openapi: 3.0.0\ninfo:\n  title: API\n", "openapi"},
+		{"spec", "{\"swagger\": \"2.0\"}", "swagger"},
+		// The content isn't recognized, the extension is claimed by a single format
+		{"notes.md", "# API\n", "blueprint"},
+	}
+
+	for _, check := range checks {
+		filename := filepath.Join(dir, check.Filename)
+		if !assert.Nil(t, ioutil.WriteFile(filename, []byte(check.Content), 0644)) {
+			return
+		}
+
+		f, err := DetectFormat(filename)
+
+		if assert.Nil(t, err, check.Filename) {
+			assert.Exactly(t, check.Expected, f.Name, check.Filename)
+		}
+	}
+
+	// The extension is claimed by several formats
+	filename := filepath.Join(dir, "unknown.json")
+	if assert.Nil(t, ioutil.WriteFile(filename, []byte("{}"), 0644)) {
+		_, err = DetectFormat(filename)
+		assert.NotNil(t, err)
+	}
+}
+
+func TestFormatByName(t *testing.T) {
+	f, err := FormatByName("RAML")
+
+	if assert.Nil(t, err) {
+		assert.Exactly(t, "raml", f.Name)
+	}
+
+	_, err = FormatByName("wsdl")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,43 @@
+package parser
+
+import (
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/definition"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/parser/transformer"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/parser/walker"
+)
+
+func init() {
+	RegisterFormat(Format{
+		Name:           "openapi",
+		Extensions:     []string{".yaml", ".yml", ".json"},
+		Detect:         isOpenAPI,
+		NewParser:      NewOpenAPIParser,
+		NewTransformer: transformer.NewOpenAPITransformer,
+	})
+}
+
+//OpenAPIParser Concrete's parser definition
+type OpenAPIParser struct{}
+
+//NewOpenAPIParser Creates an OpenAPI 3.x parser
+func NewOpenAPIParser() Parser {
+	return &OpenAPIParser{}
+}
+
+//Parse Concrete implementation of the Parser.Parse method
+func (op OpenAPIParser) Parse(filename string, tra transformer.Transformer) (def *definition.Api, err error) {
+	var data interface{}
+
+	if data, err = readDocument(filename); err != nil {
+		return
+	}
+
+	def, err = tra.Transform(walker.NewObjectWalker(data))
+
+	return
+}
+
+//isOpenAPI Checks if the content is an OpenAPI's document, which is identified by its openapi root key
+func isOpenAPI(content []byte) bool {
+	return hasRootKey(content, "openapi")
+}
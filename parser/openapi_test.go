@@ -0,0 +1,213 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/definition"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/parser/transformer"
+	"github.com/stretchr/testify/assert"
+)
+
+type OpenAPIParserTest struct {
+	apiDef *definition.Api
+}
+
+func TestOpenAPIParser_Integration(t *testing.T) {
+	p := NewOpenAPIParser()
+
+	def, err := p.Parse("testdata/openapi/petstore.yaml", transformer.NewOpenAPITransformer())
+
+	if !assert.Nil(t, err, "OpenAPI parsing failed") {
+		return
+	}
+
+	parserTest := &OpenAPIParserTest{
+		apiDef: def,
+	}
+
+	t.Run("Title", parserTest.assertTitle)
+	t.Run("Version", parserTest.assertVersion)
+	t.Run("BaseURI", parserTest.assertBaseURI)
+	t.Run("Protocols", parserTest.assertProtocols)
+	t.Run("CustomTypes", parserTest.assertCustomTypes)
+	t.Run("SecuritySchemes", parserTest.assertSecuritySchemes)
+	t.Run("SecuredBy", parserTest.assertSecuredBy)
+	t.Run("ResourceGroups", parserTest.assertResourceGroups)
+	t.Run("Resources", parserTest.assertResources)
+}
+
+func TestOpenAPIParser_UnquotedVersion(t *testing.T) {
+	// The version written unquoted is decoded as a number, e.g. 3.0 is 3
+	def, err := NewOpenAPIParser().Parse("testdata/openapi/unquoted.yaml", transformer.NewOpenAPITransformer())
+
+	if assert.Nil(t, err) {
+		assert.Exactly(t, "Unquoted", def.Title)
+	}
+}
+
+func TestOpenAPIParser_RecursiveAllOf(t *testing.T) {
+	// The schema composed of itself is only expanded once
+	def, err := NewOpenAPIParser().Parse("testdata/openapi/recursive.yaml", transformer.NewOpenAPITransformer())
+
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	name := []definition.CustomTypeProperty{{Name: "name", Type: "string"}}
+	if assert.Len(t, def.CustomTypes, 1) {
+		assert.Exactly(t, name, def.CustomTypes[0].Properties)
+	}
+
+	body := def.ResourceGroups[0].Resources[0].Actions[0].Transactions[0].Response.Body[0]
+	assert.Exactly(t, "Node", body.Type)
+}
+
+func (op *OpenAPIParserTest) assertTitle(t *testing.T) {
+	t.Parallel()
+
+	assert.Exactly(t, "Swagger Petstore", op.apiDef.Title)
+}
+
+func (op *OpenAPIParserTest) assertVersion(t *testing.T) {
+	t.Parallel()
+
+	assert.Exactly(t, "1.0.0", op.apiDef.Version)
+}
+
+func (op *OpenAPIParserTest) assertBaseURI(t *testing.T) {
+	t.Parallel()
+
+	assert.Exactly(t, "https://{environment}.example.com/v1", op.apiDef.BaseURI)
+	assert.Exactly(t, []definition.Parameter{
+		{
+			Name:        "environment",
+			Description: "The environment used to consume the API",
+			Type:        "string",
+			Required:    true,
+			Example:     "api",
+		},
+	}, op.apiDef.BaseURIParameters)
+}
+
+func (op *OpenAPIParserTest) assertProtocols(t *testing.T) {
+	t.Parallel()
+
+	assert.Exactly(t, []definition.Protocol{"https", "http"}, op.apiDef.Protocols)
+}
+
+func (op *OpenAPIParserTest) assertCustomTypes(t *testing.T) {
+	t.Parallel()
+
+	if !assert.Len(t, op.apiDef.CustomTypes, 3) {
+		return
+	}
+
+	assert.Exactly(t, definition.CustomType{
+		Name:        "Pet",
+		Description: "A pet of the store",
+		Type:        "object",
+		Properties: []definition.CustomTypeProperty{
+			{Name: "id", Type: "integer", Required: true},
+			{Name: "name", Type: "string", Required: true, Example: "Garfield"},
+			{Name: "tag", Type: "string"},
+		},
+	}, op.apiDef.CustomTypes[1])
+
+	assert.Exactly(t, "Pet[]", op.apiDef.CustomTypes[2].Type)
+}
+
+func (op *OpenAPIParserTest) assertSecuritySchemes(t *testing.T) {
+	t.Parallel()
+
+	if assert.Len(t, op.apiDef.SecuritySchemes, 1) {
+		scheme := op.apiDef.SecuritySchemes[0]
+
+		assert.Exactly(t, "petstore_auth", scheme.Name)
+		assert.Exactly(t, "oauth2", scheme.Type)
+		assert.Exactly(t, "OAuth 2.0 used to consume the API", scheme.Description)
+		assert.Exactly(t, "flows", scheme.Settings[0].Name)
+	}
+}
+
+func (op *OpenAPIParserTest) assertSecuredBy(t *testing.T) {
+	t.Parallel()
+
+	assert.Exactly(t, []definition.Option{
+		{
+			Name:       "petstore_auth",
+			Parameters: map[string]interface{}{"scopes": []interface{}{"read:pets"}},
+		},
+	}, op.apiDef.SecuredBy)
+}
+
+func (op *OpenAPIParserTest) assertResourceGroups(t *testing.T) {
+	t.Parallel()
+
+	if assert.Len(t, op.apiDef.ResourceGroups, 2) {
+		assert.Exactly(t, "pets", op.apiDef.ResourceGroups[0].Title)
+		assert.Exactly(t, "Everything about your Pets", op.apiDef.ResourceGroups[0].Description)
+		assert.Exactly(t, "", op.apiDef.ResourceGroups[1].Title)
+		assert.Exactly(t, "/health", op.apiDef.ResourceGroups[1].Resources[0].Href.Path)
+	}
+}
+
+func (op *OpenAPIParserTest) assertResources(t *testing.T) {
+	t.Parallel()
+
+	resources := op.apiDef.ResourceGroups[0].Resources
+	if !assert.Len(t, resources, 2) {
+		return
+	}
+
+	collection := resources[0]
+	assert.Exactly(t, "/pets", collection.Href.FullPath)
+	if assert.Len(t, collection.Actions, 2) {
+		list := collection.Actions[0]
+
+		assert.Exactly(t, "GET", list.Method)
+		assert.Exactly(t, "List all pets", list.Title)
+		assert.Exactly(t, "limit", list.Href.Parameters[0].Name)
+		assert.Exactly(t, float64(100), *list.Href.Parameters[0].Max)
+		assert.Exactly(t, "petstore_auth", list.SecuredBy[0].Name)
+
+		if assert.Len(t, list.Transactions, 2) {
+			assert.Exactly(t, 200, list.Transactions[0].Response.StatusCode)
+			assert.Exactly(t, "Pets", list.Transactions[0].Response.Body[0].Type)
+			assert.Exactly(t, "x-next", list.Transactions[0].Response.Headers[0].Name)
+			assert.Exactly(t, 0, list.Transactions[1].Response.StatusCode)
+			assert.Exactly(t, "Error", list.Transactions[1].Response.Body[0].Type)
+		}
+
+		create := collection.Actions[1]
+
+		assert.Exactly(t, "POST", create.Method)
+		assert.Exactly(t, "The pet to be created", create.Transactions[0].Request.Description)
+		assert.Exactly(t, definition.Body{
+			Type:      "Pet",
+			MediaType: "application/json",
+			Example:   "{\n    \"id\": 1,\n    \"name\": \"Garfield\"\n}",
+		}, create.Transactions[0].Request.Body[0])
+	}
+
+	item := resources[1]
+	assert.Exactly(t, "/pets/{petId}", item.Href.FullPath)
+	assert.Exactly(t, []definition.Parameter{
+		{
+			Name:        "petId",
+			Description: "The id of the pet to retrieve",
+			Type:        "string",
+			Required:    true,
+		},
+	}, item.Href.Parameters)
+
+	if assert.Len(t, item.Actions, 1) {
+		assert.Nil(t, item.Actions[0].SecuredBy)
+		assert.Exactly(t, &definition.CustomType{
+			Type: "object",
+			Properties: []definition.CustomTypeProperty{
+				{Name: "name", Type: "string", Required: true},
+				{Name: "tag", Type: "string"},
+			},
+		}, item.Actions[0].Transactions[0].Response.Body[0].CustomType)
+	}
+}
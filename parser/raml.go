@@ -1,11 +1,25 @@
 package parser
 
 import (
+	"bytes"
+	"io/ioutil"
+
 	"github.com/Jumpscale/go-raml/raml"
 	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/definition"
 	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/parser/transformer"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/parser/walker"
 )
 
+func init() {
+	RegisterFormat(Format{
+		Name:           "raml",
+		Extensions:     []string{".raml"},
+		Detect:         isRaml,
+		NewParser:      NewRamlParser,
+		NewTransformer: transformer.NewRamlTransformer,
+	})
+}
+
 //RamlParser  Concrete's parser definition
 type RamlParser struct{}
 
@@ -16,6 +30,24 @@ func NewRamlParser() Parser {
 
 //Parse Concrete implementation of the Parser.Parse method
 func (rp RamlParser) Parse(filename string, tra transformer.Transformer) (def *definition.Api, err error) {
+	var raw []byte
+
+	if raw, err = ioutil.ReadFile(filename); err != nil {
+		return
+	}
+
+	// RAML 0.8's documents are given to the transformer as a generic document
+	if isRaml08(raw) {
+		var data interface{}
+		if data, err = readRaml08(filename); err != nil {
+			return
+		}
+
+		def, err = tra.Transform(walker.NewObjectWalker(data))
+
+		return
+	}
+
 	ramlDef := new(raml.APIDefinition)
 
 	if err = raml.ParseFile(filename, ramlDef); err != nil {
@@ -26,3 +58,8 @@ func (rp RamlParser) Parse(filename string, tra transformer.Transformer) (def *d
 
 	return
 }
+
+//isRaml Checks if the content is a RAML's document, which is identified by its #%RAML header
+func isRaml(content []byte) bool {
+	return bytes.HasPrefix(bytes.TrimPrefix(content, []byte("\ufeff")), []byte("#%RAML"))
+}
@@ -0,0 +1,96 @@
+package parser
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// includeRe Matches the values included from other files, e.g. "schema: !include schemas/user.json"
+var includeRe = regexp.MustCompile(`^(\s*)(-|[^#\s].*?:)\s+!include\s+(\S+)\s*$`)
+
+//isRaml08 Checks if the content is a RAML 0.8's document, which isn't supported by go-raml
+func isRaml08(content []byte) bool {
+	return bytes.HasPrefix(bytes.TrimPrefix(content, []byte("\ufeff")), []byte("#%RAML 0.8"))
+}
+
+//readRaml08 Reads the RAML 0.8's document with its included files and returns its normalized content
+func readRaml08(filename string) (data interface{}, err error) {
+	var source string
+
+	if source, err = includeFiles(filename, nil); err != nil {
+		return
+	}
+
+	if data, err = decodeDocument([]byte(source)); err != nil {
+		err = errors.Wrapf(err, "Cannot parse the document %s", filename)
+	}
+
+	return
+}
+
+//includeFiles Returns the file's content replacing the !include tags by the content of the files included.
+//RAML and YAML files are included as nested YAML, any other file (e.g. JSON schemas) as a literal block.
+//The stack holds the files including this one, a file including itself, even indirectly, is an error
+func includeFiles(filename string, stack []string) (source string, err error) {
+	var raw []byte
+
+	filename = filepath.Clean(filename)
+	for _, f := range stack {
+		if f == filename {
+			err = errors.Errorf("The file %s is included recursively: %s", filename, strings.Join(append(stack, filename), " -> "))
+			return
+		}
+	}
+
+	if raw, err = ioutil.ReadFile(filename); err != nil {
+		return
+	}
+
+	lines := strings.Split(strings.Replace(string(raw), "\r\n", "\n", -1), "\n")
+
+	var out []string
+	for _, line := range lines {
+		m := includeRe.FindStringSubmatch(line)
+		if m == nil {
+			out = append(out, line)
+			continue
+		}
+
+		indent, key, included := m[1], m[2], filepath.Join(filepath.Dir(filename), m[3])
+
+		// The content is nested within the key, which is indented further when declared in a list item
+		nested := indent + "  "
+		if strings.HasPrefix(key, "- ") {
+			nested += strings.Repeat(" ", len(key)-len(strings.TrimLeft(key, "- ")))
+		}
+
+		var content string
+		if content, err = includeFiles(included, append(stack, filename)); err != nil {
+			return
+		}
+
+		switch strings.ToLower(filepath.Ext(included)) {
+		case ".raml", ".yaml", ".yml":
+			out = append(out, indent+key)
+		default:
+			out = append(out, indent+key+" |")
+		}
+
+		for _, l := range strings.Split(strings.TrimRight(content, "\n"), "\n") {
+			// The RAML's header of the included fragments is a comment
+			if strings.HasPrefix(l, "#%RAML") {
+				continue
+			}
+			out = append(out, nested+l)
+		}
+	}
+
+	source = strings.Join(out, "\n")
+
+	return
+}
@@ -0,0 +1,149 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/definition"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/parser/transformer"
+	"github.com/stretchr/testify/assert"
+)
+
+type Raml08ParserTest struct {
+	apiDef *definition.Api
+}
+
+func TestRamlParser_Raml08_Integration(t *testing.T) {
+	p := NewRamlParser()
+
+	def, err := p.Parse("testdata/raml08/api.raml", transformer.NewRamlTransformer())
+
+	if !assert.Nil(t, err, "RAML 0.8 parsing failed") {
+		return
+	}
+
+	parserTest := &Raml08ParserTest{
+		apiDef: def,
+	}
+
+	t.Run("Title", parserTest.assertTitle)
+	t.Run("Documentation", parserTest.assertDocumentation)
+	t.Run("CustomTypes", parserTest.assertCustomTypes)
+	t.Run("Traits", parserTest.assertTraits)
+	t.Run("SecuredBy", parserTest.assertSecuredBy)
+	t.Run("Resources", parserTest.assertResources)
+}
+
+func TestRamlParser_Raml08_RecursiveInclude(t *testing.T) {
+	// The resource includes the document which includes it
+	_, err := NewRamlParser().Parse("testdata/raml08/recursive.raml", transformer.NewRamlTransformer())
+
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "included recursively")
+	}
+}
+
+func (rp *Raml08ParserTest) assertTitle(t *testing.T) {
+	t.Parallel()
+
+	assert.Exactly(t, "Legacy API", rp.apiDef.Title)
+	assert.Exactly(t, "v1", rp.apiDef.Version)
+	assert.Exactly(t, "https://api.example.com/{version}", rp.apiDef.BaseURI)
+	assert.Exactly(t, []definition.Protocol{"HTTPS"}, rp.apiDef.Protocols)
+	assert.Exactly(t, []definition.MediaType{"application/json"}, rp.apiDef.MediaTypes)
+}
+
+func (rp *Raml08ParserTest) assertDocumentation(t *testing.T) {
+	t.Parallel()
+
+	assert.Exactly(t, []definition.Section{
+		{Title: "Getting started", Content: "Request an API key from the support team."},
+	}, rp.apiDef.Documentation)
+}
+
+func (rp *Raml08ParserTest) assertCustomTypes(t *testing.T) {
+	t.Parallel()
+
+	assert.Exactly(t, []definition.CustomType{
+		{
+			Name:        "User",
+			Description: "A user of the API",
+			Type:        "object",
+			Kind:        definition.ObjectKind,
+			Properties: []definition.CustomTypeProperty{
+				{Name: "id", Type: "integer", Required: true, Description: "The user's id"},
+				{Name: "name", Type: "string"},
+			},
+		},
+	}, rp.apiDef.CustomTypes)
+}
+
+func (rp *Raml08ParserTest) assertTraits(t *testing.T) {
+	t.Parallel()
+
+	var min = float64(1)
+
+	if assert.Len(t, rp.apiDef.Traits, 1) {
+		assert.Exactly(t, "paged", rp.apiDef.Traits[0].Name)
+		assert.Exactly(t, []definition.Parameter{
+			{Name: "limit", Type: "integer", Min: &min, Example: 10},
+		}, rp.apiDef.Traits[0].Href.Parameters)
+	}
+}
+
+func (rp *Raml08ParserTest) assertSecuredBy(t *testing.T) {
+	t.Parallel()
+
+	assert.Exactly(t, []definition.Option{{Name: "basic"}}, rp.apiDef.SecuredBy)
+	assert.Exactly(t, "Basic Authentication", rp.apiDef.SecuritySchemes[0].Type)
+}
+
+func (rp *Raml08ParserTest) assertResources(t *testing.T) {
+	t.Parallel()
+
+	if !assert.Len(t, rp.apiDef.ResourceGroups, 1) {
+		return
+	}
+
+	users := rp.apiDef.ResourceGroups[0].Resources[0]
+	assert.Exactly(t, "Users", users.Title)
+	assert.Exactly(t, "The users collection", users.Description)
+
+	if !assert.Len(t, users.Actions, 2) {
+		return
+	}
+
+	list := users.Actions[0]
+	assert.Exactly(t, "GET", list.Method)
+	assert.Exactly(t, "Lists the users", list.Description)
+	assert.Exactly(t, []definition.Option{{Name: "paged"}}, list.Is)
+	if assert.Len(t, list.Transactions[0].Response.Body, 1) {
+		body := list.Transactions[0].Response.Body[0]
+		assert.Exactly(t, "User", body.Type)
+		assert.Exactly(t, definition.MediaType("application/json"), body.MediaType)
+		assert.Exactly(t, "The users of the page", body.Description)
+		assert.Exactly(t, "[{\"id\": 1, \"name\": \"Alice\"}]\n", body.Example)
+	}
+
+	// The optional methods of the resource type only apply to the methods declared, there's no DELETE
+	create := users.Actions[1]
+	assert.Exactly(t, "POST", create.Method)
+	assert.Exactly(t, "Creates a user", create.Description)
+	assert.Exactly(t, []definition.Body{
+		{
+			MediaType: "application/x-www-form-urlencoded",
+			CustomType: &definition.CustomType{
+				Type:       "object",
+				Properties: []definition.CustomTypeProperty{{Name: "name", Type: "string", Required: true}},
+			},
+		},
+	}, create.Transactions[0].Request.Body)
+	assert.Exactly(t, 201, create.Transactions[0].Response.StatusCode)
+
+	user := users.Resources[0]
+	assert.Exactly(t, "/users/{userId}", user.Href.FullPath)
+	assert.Exactly(t, []definition.Parameter{
+		{Name: "userId", Description: "The user's id", Type: "integer", Required: true},
+	}, user.Href.Parameters)
+	assert.Exactly(t, "X-Request-Id", user.Actions[0].Transactions[0].Request.Headers[0].Name)
+	assert.Exactly(t, "User", user.Actions[0].Transactions[0].Response.Body[0].Type)
+}
@@ -93,6 +93,7 @@ func assertCustomTypes(t *testing.T) {
 			Name:        "Custom",
 			Description: "A custom type",
 			Type:        "string",
+			Kind:        definition.ScalarKind,
 			Default:     nil,
 			Examples:    nil,
 		},
@@ -100,6 +101,7 @@ func assertCustomTypes(t *testing.T) {
 			Name:        "Example",
 			Description: "An example type loaded as Library",
 			Type:        "array",
+			Kind:        definition.ArrayKind,
 			Default:     nil,
 			Examples:    nil,
 		},
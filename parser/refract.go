@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/definition"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/parser/transformer"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/parser/walker"
+)
+
+func init() {
+	RegisterFormat(Format{
+		Name:       "refract",
+		Extensions: []string{".json"},
+		Detect:     isRefract,
+		NewParser:  NewRefractParser,
+		// The document was already parsed into API Elements (e.g. by drafter), so the blueprint's transformer is used
+		NewTransformer: transformer.NewBlueprintTransformer,
+	})
+}
+
+//RefractParser Concrete's parser definition, the document is an API Elements (refract) JSON already parsed by drafter or another tool
+type RefractParser struct {
+	diagnostics Diagnostics
+}
+
+//NewRefractParser Creates an API Elements parser
+func NewRefractParser() Parser {
+	return &RefractParser{}
+}
+
+//Parse Concrete implementation of the Parser.Parse method
+func (rp *RefractParser) Parse(filename string, tra transformer.Transformer) (def *definition.Api, err error) {
+	var data interface{}
+
+	rp.diagnostics = nil
+
+	if data, err = readRefract(filename); err != nil {
+		return
+	}
+
+	el := walker.NewObjectWalker(data)
+
+	// The source isn't available, so the annotations can't be located
+	if rp.diagnostics = annotations(&el, nil); len(rp.diagnostics.Errors()) > 0 {
+		err = rp.diagnostics.Errors()
+		return
+	}
+
+	def, err = tra.Transform(el)
+
+	return
+}
+
+//Diagnostics Returns the warnings and errors annotated in the last document parsed
+func (rp *RefractParser) Diagnostics() Diagnostics {
+	return rp.diagnostics
+}
+
+//isRefract Checks if the content is an API Elements' parse result, which is identified by its element root key
+func isRefract(content []byte) bool {
+	var data interface{}
+
+	if err := json.Unmarshal(content, &data); err != nil {
+		return false
+	}
+
+	root, isMap := data.(map[string]interface{})
+
+	return isMap && root["element"] == "parseResult"
+}
+
+//readRefract Reads the JSON document, decoded as drafter's output is by the blueprint parser
+func readRefract(filename string) (data interface{}, err error) {
+	var raw []byte
+
+	if raw, err = ioutil.ReadFile(filename); err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(raw, &data); err != nil {
+		err = errors.Wrapf(err, "Cannot parse the document %s", filename)
+	}
+
+	return
+}
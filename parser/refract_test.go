@@ -0,0 +1,59 @@
+package parser
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/definition"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/parser/transformer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefractParser_Integration(t *testing.T) {
+	// Drafter writes refract 0.6 or, since API Elements 1.0, wraps the meta and attributes' values into elements
+	for _, filename := range []string{"testdata/blueprint/simple.json", "testdata/blueprint/simple-1.0.json"} {
+		p := NewRefractParser()
+
+		def, err := p.Parse(filename, transformer.NewBlueprintTransformer())
+
+		if !assert.Nil(t, err, "API Elements parsing failed") {
+			return
+		}
+		assert.IsType(t, &definition.Api{}, def)
+
+		// The API Elements of the blueprint must be transformed as the blueprint itself
+		parserTest := &BlueprintParserTest{
+			apiDef: def,
+		}
+
+		t.Run(filename, func(t *testing.T) {
+			t.Run("Title", parserTest.assertTitle)
+			t.Run("Version", parserTest.assertVersion)
+			t.Run("BaseURI", parserTest.assertBaseURI)
+			t.Run("Protocols", parserTest.assertProtocols)
+			t.Run("Metadata", parserTest.assertMetadata)
+			t.Run("Documentation", parserTest.assertDocumentation)
+			t.Run("ResourceGroups", parserTest.assertResourceGroups)
+			t.Run("Resources", parserTest.assertResources)
+		})
+	}
+}
+
+func TestRefractParser_Detect(t *testing.T) {
+	checks := []struct {
+		Filename string
+		Expected bool
+	}{
+		{"testdata/blueprint/simple.json", true},
+		{"testdata/blueprint/simple-1.0.json", true},
+		{"testdata/openapi/petstore.yaml", false},
+	}
+
+	for _, check := range checks {
+		content, err := ioutil.ReadFile(check.Filename)
+
+		if assert.Nil(t, err) {
+			assert.Exactly(t, check.Expected, isRefract(content), check.Filename)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package parser
+
+import (
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/definition"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/parser/transformer"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/parser/walker"
+)
+
+func init() {
+	RegisterFormat(Format{
+		Name:           "swagger",
+		Extensions:     []string{".yaml", ".yml", ".json"},
+		Detect:         isSwagger,
+		NewParser:      NewSwaggerParser,
+		NewTransformer: transformer.NewSwaggerTransformer,
+	})
+}
+
+//SwaggerParser Concrete's parser definition
+type SwaggerParser struct{}
+
+//NewSwaggerParser Creates a Swagger 2.0 parser
+func NewSwaggerParser() Parser {
+	return &SwaggerParser{}
+}
+
+//Parse Concrete implementation of the Parser.Parse method
+func (sp SwaggerParser) Parse(filename string, tra transformer.Transformer) (def *definition.Api, err error) {
+	var data interface{}
+
+	if data, err = readDocument(filename); err != nil {
+		return
+	}
+
+	def, err = tra.Transform(walker.NewObjectWalker(data))
+
+	return
+}
+
+//isSwagger Checks if the content is a Swagger's document, which is identified by its swagger root key
+func isSwagger(content []byte) bool {
+	return hasRootKey(content, "swagger")
+}
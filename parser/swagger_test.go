@@ -0,0 +1,185 @@
+package parser
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/definition"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/parser/transformer"
+	"github.com/stretchr/testify/assert"
+)
+
+type SwaggerParserTest struct {
+	apiDef *definition.Api
+}
+
+func TestSwaggerParser_Integration(t *testing.T) {
+	p := NewSwaggerParser()
+
+	def, err := p.Parse("testdata/swagger/petstore.yaml", transformer.NewSwaggerTransformer())
+
+	if !assert.Nil(t, err, "Swagger parsing failed") {
+		return
+	}
+
+	parserTest := &SwaggerParserTest{
+		apiDef: def,
+	}
+
+	t.Run("Title", parserTest.assertTitle)
+	t.Run("BaseURI", parserTest.assertBaseURI)
+	t.Run("Protocols", parserTest.assertProtocols)
+	t.Run("MediaTypes", parserTest.assertMediaTypes)
+	t.Run("CustomTypes", parserTest.assertCustomTypes)
+	t.Run("SecuritySchemes", parserTest.assertSecuritySchemes)
+	t.Run("Resources", parserTest.assertResources)
+}
+
+func TestSwaggerParser_Detect(t *testing.T) {
+	checks := []struct {
+		Filename string
+		Expected bool
+	}{
+		{"testdata/swagger/petstore.yaml", true},
+		{"testdata/openapi/petstore.yaml", false},
+	}
+
+	for _, check := range checks {
+		content, err := ioutil.ReadFile(check.Filename)
+
+		if assert.Nil(t, err) {
+			assert.Exactly(t, check.Expected, isSwagger(content), check.Filename)
+		}
+	}
+}
+
+func TestSwaggerParser_UnquotedVersion(t *testing.T) {
+	// The version written unquoted is decoded as a number, e.g. 2.0 is 2
+	def, err := NewSwaggerParser().Parse("testdata/swagger/unquoted.yaml", transformer.NewSwaggerTransformer())
+
+	if assert.Nil(t, err) {
+		assert.Exactly(t, "Unquoted", def.Title)
+	}
+}
+
+func TestSwaggerParser_PathBody(t *testing.T) {
+	def, err := NewSwaggerParser().Parse("testdata/swagger/path-body.yaml", transformer.NewSwaggerTransformer())
+
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	bodies := make(map[string]definition.Request)
+	for _, action := range def.ResourceGroups[0].Resources[0].Actions {
+		if assert.Len(t, action.Transactions, 1) {
+			bodies[action.Method] = action.Transactions[0].Request
+		}
+	}
+
+	// The path item's body is the request body, unless the operation declares its own
+	if assert.Len(t, bodies["PUT"].Body, 1) {
+		assert.Exactly(t, "The pet to be saved", bodies["PUT"].Description)
+		assert.Exactly(t, "Pet", bodies["PUT"].Body[0].Type)
+	}
+
+	if assert.Len(t, bodies["PATCH"].Body, 1) {
+		assert.Exactly(t, "The pet's changes", bodies["PATCH"].Description)
+		assert.Exactly(t, "object", bodies["PATCH"].Body[0].Type)
+	}
+}
+
+func (sp *SwaggerParserTest) assertTitle(t *testing.T) {
+	t.Parallel()
+
+	assert.Exactly(t, "Swagger Petstore", sp.apiDef.Title)
+	assert.Exactly(t, "1.0.0", sp.apiDef.Version)
+}
+
+func (sp *SwaggerParserTest) assertBaseURI(t *testing.T) {
+	t.Parallel()
+
+	assert.Exactly(t, "https://petstore.example.com/v1", sp.apiDef.BaseURI)
+}
+
+func (sp *SwaggerParserTest) assertProtocols(t *testing.T) {
+	t.Parallel()
+
+	assert.Exactly(t, []definition.Protocol{"https", "http"}, sp.apiDef.Protocols)
+}
+
+func (sp *SwaggerParserTest) assertMediaTypes(t *testing.T) {
+	t.Parallel()
+
+	assert.Exactly(t, []definition.MediaType{"application/json"}, sp.apiDef.MediaTypes)
+}
+
+func (sp *SwaggerParserTest) assertCustomTypes(t *testing.T) {
+	t.Parallel()
+
+	if !assert.Len(t, sp.apiDef.CustomTypes, 3) {
+		return
+	}
+
+	assert.Exactly(t, definition.CustomType{
+		Name: "Pet",
+		Type: "object",
+		Properties: []definition.CustomTypeProperty{
+			{Name: "id", Type: "integer", Required: true},
+			{Name: "name", Type: "string", Required: true},
+			{Name: "owner", Type: "Owner"},
+		},
+	}, sp.apiDef.CustomTypes[1])
+
+	assert.Exactly(t, "Pet[]", sp.apiDef.CustomTypes[2].Type)
+}
+
+func (sp *SwaggerParserTest) assertSecuritySchemes(t *testing.T) {
+	t.Parallel()
+
+	if assert.Len(t, sp.apiDef.SecuritySchemes, 2) {
+		assert.Exactly(t, "api_key", sp.apiDef.SecuritySchemes[0].Name)
+		assert.Exactly(t, "apiKey", sp.apiDef.SecuritySchemes[0].Type)
+		assert.Exactly(t, "petstore_auth", sp.apiDef.SecuritySchemes[1].Name)
+		assert.Exactly(t, "oauth2", sp.apiDef.SecuritySchemes[1].Type)
+		assert.Exactly(t, "authorizationUrl", sp.apiDef.SecuritySchemes[1].Settings[0].Name)
+	}
+}
+
+func (sp *SwaggerParserTest) assertResources(t *testing.T) {
+	t.Parallel()
+
+	if !assert.Len(t, sp.apiDef.ResourceGroups, 1) {
+		return
+	}
+
+	resources := sp.apiDef.ResourceGroups[0].Resources
+	if !assert.Len(t, resources, 2) {
+		return
+	}
+
+	list := resources[0].Actions[0]
+	assert.Exactly(t, "GET", list.Method)
+	assert.Exactly(t, "limit", list.Href.Parameters[0].Name)
+	assert.Exactly(t, "integer", list.Href.Parameters[0].Type)
+	assert.Exactly(t, "x-next", list.Transactions[0].Response.Headers[0].Name)
+	assert.Exactly(t, definition.Body{
+		Type:      "Pets",
+		MediaType: "application/json",
+		Example:   "[\n    {\n        \"id\": 1,\n        \"name\": \"Garfield\"\n    }\n]",
+	}, list.Transactions[0].Response.Body[0])
+
+	create := resources[0].Actions[1]
+	assert.Exactly(t, "The pet to be created", create.Transactions[0].Request.Description)
+	assert.Exactly(t, "Pet", create.Transactions[0].Request.Body[0].Type)
+
+	upload := resources[1]
+	assert.Exactly(t, "petId", upload.Href.Parameters[0].Name)
+	assert.Exactly(t, &definition.CustomType{
+		Type: "object",
+		Properties: []definition.CustomTypeProperty{
+			{Name: "caption", Type: "string"},
+			{Name: "file", Type: "string", Required: true},
+		},
+	}, upload.Actions[0].Transactions[0].Request.Body[0].CustomType)
+	assert.Exactly(t, definition.MediaType("multipart/form-data"), upload.Actions[0].Transactions[0].Request.Body[0].MediaType)
+}
@@ -1,18 +1,28 @@
 package transformer
 
 import (
+	"encoding/json"
+	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/pkg/errors"
 	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/definition"
 	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/parser/walker"
 )
 
-type BlueprintTransformer struct{}
+//headingRe Matches the markdown's headings, e.g. ## Authentication
+var headingRe = regexp.MustCompile(`^#{1,6}\s+(.+?)\s*#*\s*$`)
+
+type BlueprintTransformer struct {
+	// dataStructures are the named data structures by their names, names keeps their order
+	dataStructures map[string]*walker.ObjectWalker
+	names          []string
+}
 
 func NewBlueprintTransformer() Transformer {
-	return new(BlueprintTransformer)
+	return &BlueprintTransformer{dataStructures: make(map[string]*walker.ObjectWalker)}
 }
 
 func (f *BlueprintTransformer) Transform(data interface{}) (def *definition.Api, err error) {
@@ -29,52 +39,62 @@ func (f *BlueprintTransformer) Transform(data interface{}) (def *definition.Api,
 
 //Blueprint transformation methods, names are bound to what they parse
 
+//The resources declared outside groups are gathered in an implicit untitled group, placed where the first one is declared
 func (f *BlueprintTransformer) resourceGroups(el *walker.ObjectWalker, apiDef *definition.Api) {
-	children := filterContentByClass("resourceGroup", el)
+	children, err := el.Path("content").Children()
+	if err != nil {
+		return
+	}
+
+	ungrouped := false
 
 	for _, child := range children {
-		g := &definition.ResourceGroup{
-			Title:       child.Path("meta.title").String(),
-			Description: f.handleDescription(child),
-		}
+		switch {
+		case hasClass("resourceGroup", child):
+			g := &definition.ResourceGroup{
+				Title:       elementString(child.Path("meta.title")),
+				Description: f.handleDescription(child),
+			}
 
-		f.resources(child, g)
-		apiDef.ResourceGroups = append(apiDef.ResourceGroups, *g)
+			f.resources(child, g)
+			apiDef.ResourceGroups = append(apiDef.ResourceGroups, *g)
+		case child.Path("element").String() == "resource" && !ungrouped:
+			ungrouped = true
+
+			g := &definition.ResourceGroup{}
+
+			f.resources(el, g)
+			apiDef.ResourceGroups = append(apiDef.ResourceGroups, *g)
+		}
 	}
 }
 
+//Resources are transformed concurrently, each one is stored at its position so the document's order is kept
 func (f *BlueprintTransformer) resources(el *walker.ObjectWalker, g *definition.ResourceGroup) {
 	children := filterContentByElement("resource", el)
-
-	cr := make(chan *definition.Resource)
-	oc := make([]string, len(children))
 	rs := make([]definition.Resource, len(children))
 
+	var wg sync.WaitGroup
+
 	for i, child := range children {
-		oc[i] = child.Path("meta.title").String()
+		wg.Add(1)
+
+		go func(i int, c *walker.ObjectWalker) {
+			defer wg.Done()
 
-		go func(c *walker.ObjectWalker) {
 			r := &definition.Resource{
-				Title:       c.Path("meta.title").String(),
+				Title:       elementString(c.Path("meta.title")),
 				Description: f.handleDescription(c),
 				Href:        f.handleHref(c),
 			}
 
 			f.resourceAction(c, r)
 
-			cr <- r
-		}(child)
+			rs[i] = *r
+		}(i, child)
 	}
 
-	for i := 0; i < len(children); i++ {
-		r := <-cr
-
-		for n := range oc {
-			if oc[n] == r.Title {
-				rs[n] = *r
-			}
-		}
-	}
+	wg.Wait()
 
 	g.Resources = rs
 }
@@ -85,8 +105,17 @@ func (f *BlueprintTransformer) resourceAction(el *walker.ObjectWalker, r *defini
 	for _, child := range children {
 		transactions, method := f.transactions(child)
 
+		// The action's attributes describe the requests which don't declare their own
+		if attributes := filterContentByElement("dataStructure", child); len(attributes) > 0 {
+			for i, tx := range transactions {
+				if len(tx.Request.Body) == 0 || tx.Request.Body[0].CustomType == nil {
+					transactions[i].Request.Body = f.handleAttributes(tx.Request.Body, attributes[0], tx.Request.Headers)
+				}
+			}
+		}
+
 		t := &definition.ResourceAction{
-			Title:        child.Path("meta.title").String(),
+			Title:        elementString(child.Path("meta.title")),
 			Description:  f.handleDescription(child),
 			Href:         f.handleHref(child),
 			Transactions: transactions,
@@ -134,29 +163,18 @@ func (f *BlueprintTransformer) transaction(el []*walker.ObjectWalker) (transacti
 }
 
 func (f *BlueprintTransformer) request(child *walker.ObjectWalker) (request definition.Request, method string) {
-	request.Title = child.Path("meta.title").String()
+	request.Title = elementString(child.Path("meta.title"))
 	request.Description = f.handleDescription(child)
 	request.Headers = f.handleHeaders(child.Path("attributes.headers"))
 
-	method = child.Path("attributes.method").String()
-	cx, err := child.Path("content").Children()
-	if err != nil {
-		return
-	}
-
-	for _, c := range cx {
-		if hasClass("messageBody", c) {
-			request.Body = []definition.Body{
-				f.handleBody(c),
-			}
-		}
-	}
+	method = elementString(child.Path("attributes.method"))
+	request.Body = f.handlePayload(child, request.Headers)
 
 	return
 }
 
 func (f *BlueprintTransformer) response(child *walker.ObjectWalker) (response definition.Response) {
-	s := child.Path("attributes.statusCode").String()
+	s := elementString(child.Path("attributes.statusCode"))
 	n, err := strconv.Atoi(s)
 	if err != nil {
 		n = 0
@@ -166,19 +184,7 @@ func (f *BlueprintTransformer) response(child *walker.ObjectWalker) (response de
 	response.Headers = f.handleHeaders(child.Path("attributes.headers"))
 	response.Description = f.handleDescription(child)
 
-	cx, err := child.Path("content").Children()
-	if err != nil {
-		return
-	}
-
-	for _, c := range cx {
-		if hasClass("messageBody", c) {
-			response.Body = []definition.Body{
-				f.handleBody(c),
-			}
-		}
-
-	}
+	response.Body = f.handlePayload(child, response.Headers)
 
 	return
 }
@@ -190,6 +196,16 @@ func (f *BlueprintTransformer) handleContent(el *walker.ObjectWalker) *definitio
 	apiDef := new(definition.Api)
 
 	children, _ := el.Path("content").Children()
+
+	// The data structures can be referenced across the API's categories, so they're collected beforehand
+	for _, child := range children {
+		if hasClass("api", child) {
+			f.collectDataStructures(child)
+		}
+	}
+
+	f.customTypes(apiDef)
+
 	for _, child := range children {
 		f.handleElements(child, apiDef)
 	}
@@ -197,9 +213,18 @@ func (f *BlueprintTransformer) handleContent(el *walker.ObjectWalker) *definitio
 	return apiDef
 }
 
-//Handle metadata extraction
+//Handle metadata extraction, the metadata are named meta or metadata (an array element) depending on the refract's version
 func (f *BlueprintTransformer) handleMetadata(el *walker.ObjectWalker) (m map[string]string) {
-	children, err := el.Path("attributes.meta").Children()
+	meta := el.Path("attributes.meta")
+	if meta.Object() == nil {
+		meta = el.Path("attributes.metadata")
+	}
+
+	if _, ok := meta.Object().(map[string]interface{}); ok {
+		meta = meta.Path("content")
+	}
+
+	children, err := meta.Children()
 	m = make(map[string]string)
 
 	if err != nil {
@@ -217,29 +242,64 @@ func (f *BlueprintTransformer) handleMetadata(el *walker.ObjectWalker) (m map[st
 func (f *BlueprintTransformer) handleElements(el *walker.ObjectWalker, apiDef *definition.Api) {
 	switch el.Path("element").String() {
 	case "category":
+		// The API can be split into several categories, the first one declaring the title and metadata prevails
 		if hasClass("api", el) {
-			f.handleTitles(el, apiDef)
+			if apiDef.Title == "" {
+				f.handleTitles(el, apiDef)
+			}
 
 			meta := f.handleMetadata(el)
-			if version, ok := meta["version"]; ok {
+			if apiDef.Metadata == nil {
+				apiDef.Metadata = make(map[string]string)
+			}
+
+			for key, value := range meta {
+				if _, ok := apiDef.Metadata[key]; !ok {
+					apiDef.Metadata[key] = value
+				}
+			}
+
+			if version, ok := meta["version"]; ok && apiDef.Version == "" {
 				apiDef.Version = version
 			}
 
-			if host, ok := meta["host"]; ok {
-				apiDef.BaseURI = host
-				proto, _ := definition.NewProtocolFromURL(host)
-				apiDef.Protocols = append(apiDef.Protocols, proto)
+			if host, ok := meta["host"]; ok && apiDef.BaseURI == "" {
+				f.handleBaseURI(host, apiDef)
 			}
 
+			apiDef.Documentation = append(apiDef.Documentation, f.handleDocumentation(el)...)
+
 			f.resourceGroups(el, apiDef)
 		}
 	}
 }
 
+//Handle the base URI given by the host's metadata, the variables of its template are the base URI's parameters
+func (f *BlueprintTransformer) handleBaseURI(host string, apiDef *definition.Api) {
+	apiDef.BaseURI = host
+
+	uri, err := definition.ParseURI(host)
+	if err != nil {
+		return
+	}
+
+	if uri.Protocol != "" {
+		apiDef.Protocols = append(apiDef.Protocols, uri.Protocol)
+	}
+
+	for _, name := range uri.Parameters {
+		apiDef.BaseURIParameters = append(apiDef.BaseURIParameters, definition.Parameter{
+			Name:     name,
+			Type:     "string",
+			Required: true,
+		})
+	}
+}
+
 //Handle the titles
 func (f *BlueprintTransformer) handleTitles(el *walker.ObjectWalker, apiDef *definition.Api) {
 	if hasClass("api", el) {
-		apiDef.Title = el.Path("meta.title").String()
+		apiDef.Title = elementString(el.Path("meta.title"))
 	}
 }
 
@@ -259,9 +319,53 @@ func (f *BlueprintTransformer) handleDescription(el *walker.ObjectWalker) string
 	return ""
 }
 
+//Handle the API's top-level copy, split into sections by its headings. The copy preceding the headings is the introduction
+func (f *BlueprintTransformer) handleDocumentation(el *walker.ObjectWalker) (sections []definition.Section) {
+	var copies []string
+	for _, child := range filterContentByElement("copy", el) {
+		copies = append(copies, child.Path("content").String())
+	}
+
+	if len(copies) == 0 {
+		return
+	}
+
+	section := &definition.Section{Title: "Introduction"}
+	var lines []string
+	fenced := false
+
+	for _, line := range strings.Split(strings.Join(copies, "\n\n"), "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			fenced = !fenced
+		}
+
+		if m := headingRe.FindStringSubmatch(line); m != nil && !fenced {
+			sections = appendSection(sections, section, lines)
+			section, lines = &definition.Section{Title: m[1]}, nil
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	sections = appendSection(sections, section, lines)
+
+	return
+}
+
+//appendSection Appends the section with the lines given as content, the introduction is left out when it's empty
+func appendSection(sections []definition.Section, section *definition.Section, lines []string) []definition.Section {
+	section.Content = strings.TrimSpace(strings.Join(lines, "\n"))
+	if section.Content == "" && section.Title == "Introduction" {
+		return sections
+	}
+
+	return append(sections, *section)
+}
+
 //Handle the href sections, including it's internal params
 func (f *BlueprintTransformer) handleHref(child *walker.ObjectWalker) (h definition.Href) {
-	href := child.Path("attributes.href")
+	href := elementValue(child.Path("attributes.href"))
 
 	if href.Value().IsValid() {
 		h.Path = href.String()
@@ -274,12 +378,16 @@ func (f *BlueprintTransformer) handleHref(child *walker.ObjectWalker) (h definit
 	}
 
 	for _, content := range contents {
+		value := content.Path("content.value")
+
 		v := &definition.Parameter{
 			Required:    contains("attributes.typeAttributes", "required", content),
-			Type:        content.Path("content.value.element").String(),
-			Example:     content.Path("content.value.content").String(),
+			Type:        msonTypeName(value),
+			Example:     value.Path("content").String(),
 			Name:        content.Path("content.key.content").String(),
-			Description: content.Path("meta.description").String(),
+			Description: elementString(content.Path("meta.description")),
+			Enum:        enumerations(value),
+			Default:     value.Path("attributes.default.content").Object(),
 		}
 
 		h.Parameters = append(h.Parameters, *v)
@@ -288,6 +396,92 @@ func (f *BlueprintTransformer) handleHref(child *walker.ObjectWalker) (h definit
 	return
 }
 
+//Handle the payload's assets and attributes, both for response and request. The bodies are described by their schemas,
+//the attributes prevail over the schemas
+func (f *BlueprintTransformer) handlePayload(child *walker.ObjectWalker, headers []definition.Header) (bodies []definition.Body) {
+	cx, err := child.Path("content").Children()
+	if err != nil {
+		return
+	}
+
+	var schemas []*walker.ObjectWalker
+	var attributes *walker.ObjectWalker
+
+	for _, c := range cx {
+		switch {
+		case hasClass("messageBody", c):
+			bodies = append(bodies, f.handleBody(c))
+		case hasClass("messageBodySchema", c):
+			schemas = append(schemas, c)
+		case c.Path("element").String() == "dataStructure":
+			attributes = c
+		}
+	}
+
+	// Each schema describes the body at its position, schemas without body describe an empty one
+	for i, schema := range schemas {
+		if i == len(bodies) {
+			bodies = append(bodies, definition.Body{
+				MediaType: definition.MediaType(elementString(schema.Path("attributes.contentType"))),
+			})
+		}
+
+		bodies[i].CustomType = f.handleSchema(schema)
+	}
+
+	if attributes != nil {
+		bodies = f.handleAttributes(bodies, attributes, headers)
+	}
+
+	return
+}
+
+//Handle the JSON schema asset, its properties are described as a custom type. Other schemas aren't described
+func (f *BlueprintTransformer) handleSchema(child *walker.ObjectWalker) *definition.CustomType {
+	var doc interface{}
+	if err := json.Unmarshal([]byte(child.Path("content").String()), &doc); err != nil {
+		return nil
+	}
+
+	// The schema's references are local to the schema itself
+	schema := walker.NewObjectWalker(doc)
+	ct := f.schemaCustomType(&OpenAPITransformer{doc: &schema}, &schema, nil)
+
+	return &ct
+}
+
+//Handle the JSON schema's element, arrays are described by their items unless they're being described (stack)
+func (f *BlueprintTransformer) schemaCustomType(schemas *OpenAPITransformer, el *walker.ObjectWalker, stack []string) (ct definition.CustomType) {
+	schema := schemas.resolve(el)
+
+	if ref := el.Path("$ref").String(); ref != "" {
+		if inStack(stack, ref) {
+			ct.Type = refName(ref)
+			return
+		}
+		stack = append(stack, ref)
+	}
+
+	ct.Type = schemas.schemaType(el)
+	ct.Description = schema.Path("description").String()
+	ct.Default = schema.Path("default").Object()
+	ct.Enum = schema.Path("enum").Object()
+
+	switch typ := schemas.schemaType(schema); {
+	case typ == "object":
+		ct.Kind = definition.ObjectKind
+		ct.Properties = schemas.handleProperties(schema, stack)
+	case strings.HasSuffix(typ, "[]"):
+		ct.Kind = definition.ArrayKind
+		items := f.schemaCustomType(schemas, schema.Path("items"), stack)
+		ct.Items = &items
+	default:
+		ct.Kind = definition.ScalarKind
+	}
+
+	return
+}
+
 //Handle body examples, both for response and request
 func (f *BlueprintTransformer) handleBody(child *walker.ObjectWalker) (body definition.Body) {
 	if child.Path("element").String() == "asset" {
@@ -303,7 +497,7 @@ func (f *BlueprintTransformer) handleBody(child *walker.ObjectWalker) (body defi
 		}
 
 		body = definition.Body{
-			MediaType: definition.MediaType(child.Path("attributes.contentType").String()),
+			MediaType: definition.MediaType(elementString(child.Path("attributes.contentType"))),
 			Example:   content,
 		}
 	}
@@ -321,8 +515,9 @@ func (f *BlueprintTransformer) handleHeaders(child *walker.ObjectWalker) (hs []d
 
 		for _, content := range contents {
 			h := definition.Header{
-				Name:    content.Path("content.key.content").String(),
-				Example: content.Path("content.value.content").String(),
+				Name:        content.Path("content.key.content").String(),
+				Description: elementString(content.Path("meta.description")),
+				Example:     content.Path("content.value.content").String(),
 			}
 
 			hs = append(hs, h)
@@ -340,22 +535,6 @@ func hasClass(s string, child *walker.ObjectWalker) bool {
 	return contains("meta.classes", s, child)
 }
 
-func contains(key, s string, child *walker.ObjectWalker) bool {
-	v := child.Path(key).Value()
-
-	if !v.IsValid() {
-		return false
-	}
-
-	for i := 0; i < v.Len(); i++ {
-		if s == v.Index(i).Interface().(string) {
-			return true
-		}
-	}
-
-	return false
-}
-
 func filterContentByClass(s string, el *walker.ObjectWalker) (xs []*walker.ObjectWalker) {
 	children, err := el.Path("content").Children()
 	if err != nil {
@@ -0,0 +1,233 @@
+package transformer
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/definition"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/parser/walker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlueprintTransformer_Transform_WrongSpecType(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewBlueprintTransformer().Transform(struct{ Title string }{Title: "Testing wrong spec type"})
+
+	assert.NotNil(t, err, "Testing wrong spec type")
+}
+
+func TestBlueprintTransformer_Transform_ResourcesOrder(t *testing.T) {
+	t.Parallel()
+
+	// Untitled resources and resources sharing their title must be kept, in the document's order
+	var resources []interface{}
+	for i := 0; i < 100; i++ {
+		title := ""
+		if i%10 == 0 {
+			title = "Notes"
+		}
+
+		resources = append(resources, map[string]interface{}{
+			"element":    "resource",
+			"meta":       map[string]interface{}{"title": title},
+			"attributes": map[string]interface{}{"href": fmt.Sprintf("/notes/%d", i)},
+		})
+	}
+
+	data := walker.NewObjectWalker(map[string]interface{}{
+		"element": "parseResult",
+		"content": []interface{}{
+			map[string]interface{}{
+				"element": "category",
+				"meta":    map[string]interface{}{"classes": []interface{}{"api"}, "title": "Notes API"},
+				"content": []interface{}{
+					map[string]interface{}{
+						"element": "category",
+						"meta":    map[string]interface{}{"classes": []interface{}{"resourceGroup"}, "title": "Notes"},
+						"content": resources,
+					},
+				},
+			},
+		},
+	})
+
+	def, err := NewBlueprintTransformer().Transform(data)
+	if !assert.Nil(t, err) || !assert.Len(t, def.ResourceGroups, 1) {
+		return
+	}
+
+	rs := def.ResourceGroups[0].Resources
+	if !assert.Len(t, rs, 100) {
+		return
+	}
+
+	for i, r := range rs {
+		assert.Exactly(t, fmt.Sprintf("/notes/%d", i), r.Href.Path)
+	}
+}
+
+func TestBlueprintTransformer_Transform_Categories(t *testing.T) {
+	t.Parallel()
+
+	resource := func(href string) map[string]interface{} {
+		return map[string]interface{}{"element": "resource", "attributes": map[string]interface{}{"href": href}}
+	}
+
+	data := walker.NewObjectWalker(map[string]interface{}{
+		"element": "parseResult",
+		"content": []interface{}{
+			map[string]interface{}{
+				"element": "category",
+				"meta":    map[string]interface{}{"classes": []interface{}{"api"}, "title": "Notes API"},
+				"content": []interface{}{resource("/notes")},
+			},
+			map[string]interface{}{
+				"element": "category",
+				"meta":    map[string]interface{}{"classes": []interface{}{"api"}, "title": "Users API"},
+				"content": []interface{}{
+					map[string]interface{}{
+						"element": "category",
+						"meta":    map[string]interface{}{"classes": []interface{}{"resourceGroup"}, "title": "Users"},
+						"content": []interface{}{resource("/users")},
+					},
+					resource("/tags"),
+				},
+			},
+		},
+	})
+
+	def, err := NewBlueprintTransformer().Transform(data)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Exactly(t, "Notes API", def.Title)
+
+	var groups, hrefs []string
+	for _, g := range def.ResourceGroups {
+		groups = append(groups, g.Title)
+		for _, r := range g.Resources {
+			hrefs = append(hrefs, r.Href.Path)
+		}
+	}
+
+	assert.Exactly(t, []string{"", "Users", ""}, groups)
+	assert.Exactly(t, []string{"/notes", "/users", "/tags"}, hrefs)
+}
+
+func TestBlueprintTransformer_Transform_Metadata(t *testing.T) {
+	t.Parallel()
+
+	member := func(key, value string) map[string]interface{} {
+		return map[string]interface{}{
+			"element": "member",
+			"content": map[string]interface{}{
+				"key":   map[string]interface{}{"element": "string", "content": key},
+				"value": map[string]interface{}{"element": "string", "content": value},
+			},
+		}
+	}
+
+	// Drafter's refract 1.0 names the metadata metadata, wrapped in an array element
+	data := walker.NewObjectWalker(map[string]interface{}{
+		"element": "parseResult",
+		"content": []interface{}{
+			map[string]interface{}{
+				"element": "category",
+				"meta":    map[string]interface{}{"classes": []interface{}{"api"}, "title": "Notes API"},
+				"attributes": map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"element": "array",
+						"content": []interface{}{
+							member("FORMAT", "1A"),
+							member("HOST", "https://{region}.example.com/{version}"),
+							member("X-Team", "Notes"),
+						},
+					},
+				},
+			},
+		},
+	})
+
+	def, err := NewBlueprintTransformer().Transform(data)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Exactly(t, map[string]string{"format": "1A", "host": "https://{region}.example.com/{version}", "x-team": "Notes"}, def.Metadata)
+	assert.Exactly(t, "https://{region}.example.com/{version}", def.BaseURI)
+	assert.Exactly(t, []definition.Protocol{"https"}, def.Protocols)
+	assert.Exactly(t, []definition.Parameter{
+		{Name: "region", Type: "string", Required: true},
+		{Name: "version", Type: "string", Required: true},
+	}, def.BaseURIParameters)
+}
+
+func TestBlueprintTransformer_Transform_NamedMembers(t *testing.T) {
+	t.Parallel()
+
+	member := func(key string, value map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"element": "member",
+			"content": map[string]interface{}{
+				"key":   map[string]interface{}{"element": "string", "content": key},
+				"value": value,
+			},
+		}
+	}
+
+	dataStructure := func(value map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{"element": "dataStructure", "content": value}
+	}
+
+	data := walker.NewObjectWalker(map[string]interface{}{
+		"element": "parseResult",
+		"content": []interface{}{
+			map[string]interface{}{
+				"element": "category",
+				"meta":    map[string]interface{}{"classes": []interface{}{"api"}, "title": "Notes API"},
+				"content": []interface{}{
+					map[string]interface{}{
+						"element": "category",
+						"meta":    map[string]interface{}{"classes": []interface{}{"dataStructures"}},
+						"content": []interface{}{
+							dataStructure(map[string]interface{}{
+								"element": "object",
+								"meta":    map[string]interface{}{"id": "User"},
+								"content": []interface{}{member("name", map[string]interface{}{"element": "string"})},
+							}),
+							dataStructure(map[string]interface{}{
+								"element": "object",
+								"meta":    map[string]interface{}{"id": "Note"},
+								"content": []interface{}{
+									member("author", map[string]interface{}{"element": "User"}),
+									member("readers", map[string]interface{}{"element": "array", "content": []interface{}{map[string]interface{}{"element": "User"}}}),
+								},
+							}),
+						},
+					},
+				},
+			},
+		},
+	})
+
+	def, err := NewBlueprintTransformer().Transform(data)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	if !assert.Len(t, def.CustomTypes, 2) {
+		return
+	}
+
+	// The members of a named type are the data structure's members
+	name := []definition.CustomTypeProperty{{Name: "name", Type: "string"}}
+	note := def.CustomTypes[1]
+	if assert.Len(t, note.Properties, 2) {
+		assert.Exactly(t, "User", note.Properties[0].Type)
+		assert.Exactly(t, name, note.Properties[0].Properties)
+		assert.Exactly(t, "User[]", note.Properties[1].Type)
+		assert.Exactly(t, name, note.Properties[1].Properties)
+	}
+}
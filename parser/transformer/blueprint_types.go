@@ -0,0 +1,248 @@
+package transformer
+
+import (
+	"strings"
+
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/definition"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/parser/walker"
+)
+
+//msonBaseTypes MSON's base types, any other type is a named type inheriting from a data structure
+var msonBaseTypes = []string{"object", "array", "enum", "string", "number", "boolean"}
+
+//Collects the named data structures, declared by the Data Structures sections and the resources' attributes, in their order
+func (f *BlueprintTransformer) collectDataStructures(el *walker.ObjectWalker) {
+	children, err := el.Path("content").Children()
+	if err != nil {
+		return
+	}
+
+	for _, child := range children {
+		switch child.Path("element").String() {
+		case "dataStructure":
+			value := dataStructureValue(child)
+			if name := elementString(value.Path("meta.id")); name != "" {
+				if _, ok := f.dataStructures[name]; !ok {
+					f.names = append(f.names, name)
+				}
+				f.dataStructures[name] = value
+			}
+		case "category", "resource":
+			f.collectDataStructures(child)
+		}
+	}
+}
+
+//Handle the named data structures, transformed into custom types
+func (f *BlueprintTransformer) customTypes(apiDef *definition.Api) {
+	for _, name := range f.names {
+		apiDef.CustomTypes = append(apiDef.CustomTypes, f.customType(f.dataStructures[name], []string{name}))
+	}
+}
+
+//Handle the data structure's element, the types inherited and included are resolved unless they're being resolved (stack)
+func (f *BlueprintTransformer) customType(el *walker.ObjectWalker, stack []string) (ct definition.CustomType) {
+	typ := el.Path("element").String()
+
+	ct.Name = elementString(el.Path("meta.id"))
+	ct.Description = elementString(el.Path("meta.description"))
+	ct.Type = typ
+	ct.Default = el.Path("attributes.default.content").Object()
+
+	if enum := enumerations(el); len(enum) > 0 {
+		ct.Enum = enum
+	}
+
+	switch typ {
+	case "object":
+		ct.Kind = definition.ObjectKind
+		ct.Properties = f.properties(el, stack)
+	case "array":
+		ct.Kind = definition.ArrayKind
+		if item := el.Path("content").Index(0); item.Object() != nil {
+			items := f.customType(item, stack)
+			ct.Items = &items
+		}
+	case "enum", "string", "number", "boolean":
+		ct.Kind = definition.ScalarKind
+		if example := scalarExample(el); example != nil {
+			ct.Examples = []interface{}{example}
+		}
+	default:
+		parent, ok := f.dataStructures[typ]
+		ok = ok && !inStack(stack, typ)
+
+		// Named types without their own members are references to the data structure
+		if ct.Name == "" && !el.Exists("content") {
+			ct.Name = typ
+			if ok {
+				ct = f.customType(parent, append(stack, typ))
+				ct.Type = typ
+			}
+			return
+		}
+
+		// Named types inherit from the data structure, its own members are merged into the inherited ones
+		ct.Kind = definition.ObjectKind
+		ct.Parents = []string{typ}
+
+		if ok {
+			inherited := f.customType(parent, append(stack, typ))
+			ct.Kind = inherited.Kind
+			ct.Items = inherited.Items
+			ct.Properties = inherited.Properties
+		}
+
+		ct.Properties = mergeProperties(ct.Properties, f.properties(el, stack))
+	}
+
+	return
+}
+
+//Handle the object's members and the data structures it includes
+func (f *BlueprintTransformer) properties(el *walker.ObjectWalker, stack []string) (props []definition.CustomTypeProperty) {
+	children, err := el.Path("content").Children()
+	if err != nil {
+		return
+	}
+
+	var own []definition.CustomTypeProperty
+
+	for _, child := range children {
+		switch child.Path("element").String() {
+		case "ref":
+			name := child.Path("content").String()
+			if included, ok := f.dataStructures[name]; ok && !inStack(stack, name) {
+				props = mergeProperties(props, f.customType(included, append(stack, name)).Properties)
+			}
+		case "member":
+			own = append(own, f.property(child, stack))
+		}
+	}
+
+	return mergeProperties(props, own)
+}
+
+//Handle the object's member, inline objects and named types are expanded into the property's properties
+func (f *BlueprintTransformer) property(member *walker.ObjectWalker, stack []string) (prop definition.CustomTypeProperty) {
+	value := member.Path("content.value")
+
+	prop.Name = member.Path("content.key.content").String()
+	prop.Description = elementString(member.Path("meta.description"))
+	prop.Required = contains("attributes.typeAttributes", "required", member)
+	prop.Type = msonTypeName(value)
+	prop.Default = value.Path("attributes.default.content").Object()
+
+	if enum := enumerations(value); len(enum) > 0 {
+		prop.Enum = enum
+	}
+
+	switch value.Path("element").String() {
+	case "array":
+		if item := value.Path("content").Index(0); item.Object() != nil {
+			prop.Properties = f.customType(item, stack).Properties
+		}
+	case "enum", "string", "number", "boolean":
+		if example := scalarExample(value); example != nil {
+			prop.Example = stringify(example)
+		}
+	default:
+		// Named types are resolved through the data structures, e.g. + author (User)
+		prop.Properties = f.customType(value, stack).Properties
+	}
+
+	return
+}
+
+//Handle the attributes of the request or response, described by the body's custom type
+func (f *BlueprintTransformer) handleAttributes(bodies []definition.Body, el *walker.ObjectWalker, headers []definition.Header) []definition.Body {
+	value := dataStructureValue(el)
+	ct := f.customType(value, nil)
+
+	if len(bodies) == 0 {
+		bodies = []definition.Body{{MediaType: contentType(headers)}}
+	}
+
+	if typ := value.Path("element").String(); !isMsonBaseType(typ) {
+		bodies[0].Type = typ
+	}
+	bodies[0].CustomType = &ct
+
+	return bodies
+}
+
+//msonTypeName Returns the type's name, arrays are named by their items' type (e.g. string[]) and enums by their values' type
+func msonTypeName(el *walker.ObjectWalker) string {
+	typ := el.Path("element").String()
+
+	switch typ {
+	case "array":
+		if item := el.Path("content").Index(0).Path("element").String(); item != "" {
+			return item + "[]"
+		}
+	case "enum":
+		if value := el.Path("attributes.enumerations.content").Index(0).Path("element").String(); value != "" {
+			return value
+		}
+		return "string"
+	}
+
+	return typ
+}
+
+//enumerations Returns the values of the enum's element
+func enumerations(el *walker.ObjectWalker) (values []interface{}) {
+	children, err := el.Path("attributes.enumerations.content").Children()
+	if err != nil {
+		return
+	}
+
+	for _, child := range children {
+		values = append(values, child.Path("content").Object())
+	}
+
+	return
+}
+
+//scalarExample Returns the scalar's value or its first sample, enums' values are elements themselves
+func scalarExample(el *walker.ObjectWalker) interface{} {
+	value := el.Path("content")
+	if el.Path("element").String() == "enum" {
+		value = value.Path("content")
+	}
+
+	if value.Object() != nil {
+		return value.Object()
+	}
+
+	return el.Path("attributes.samples.content").Index(0).Path("content").Object()
+}
+
+//dataStructureValue Returns the type held by the data structure, drafter wraps it in an array
+func dataStructureValue(el *walker.ObjectWalker) *walker.ObjectWalker {
+	content := el.Path("content")
+	if _, ok := content.Object().([]interface{}); ok {
+		return content.Index(0)
+	}
+
+	return content
+}
+
+//contentType Returns the media type given by the Content-Type header
+func contentType(headers []definition.Header) definition.MediaType {
+	for _, h := range headers {
+		if strings.EqualFold(h.Name, "Content-Type") {
+			return definition.MediaType(stringify(h.Example))
+		}
+	}
+	return ""
+}
+
+func isMsonBaseType(name string) bool {
+	for _, t := range msonBaseTypes {
+		if t == name {
+			return true
+		}
+	}
+	return false
+}
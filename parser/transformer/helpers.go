@@ -0,0 +1,147 @@
+package transformer
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/definition"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/parser/walker"
+)
+
+//generic helper methods shared by the transformers, unbound to struct
+
+// stringify Returns the string representation of a value, JSON is used for structured values
+func stringify(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case map[string]interface{}, []interface{}:
+		if b, err := json.MarshalIndent(val, "", "    "); err == nil {
+			return string(b)
+		}
+	}
+
+	return fmt.Sprint(v)
+}
+
+// refName Returns the name of the component referenced
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+// mergeProperties Returns the inherited properties overridden by the own properties, sorted by their names
+func mergeProperties(inherited, own []definition.CustomTypeProperty) (props []definition.CustomTypeProperty) {
+	if len(inherited) == 0 {
+		return own
+	}
+
+	byName := make(map[string]definition.CustomTypeProperty)
+	for _, p := range append(append([]definition.CustomTypeProperty{}, inherited...), own...) {
+		byName[p.Name] = p
+	}
+
+	var names []string
+	for name := range byName {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		props = append(props, byName[name])
+	}
+
+	return
+}
+
+func stringPtr(el *walker.ObjectWalker) *string {
+	if s, ok := el.Object().(string); ok {
+		return &s
+	}
+	return nil
+}
+
+func intPtr(el *walker.ObjectWalker) *int {
+	if f := floatPtr(el); f != nil {
+		i := int(*f)
+		return &i
+	}
+	return nil
+}
+
+func floatPtr(el *walker.ObjectWalker) *float64 {
+	var f float64
+
+	switch v := el.Object().(type) {
+	case int:
+		f = float64(v)
+	case int64:
+		f = float64(v)
+	case uint64:
+		f = float64(v)
+	case float64:
+		f = v
+	default:
+		return nil
+	}
+
+	return &f
+}
+
+func stringSlice(v interface{}) (s []string) {
+	list, _ := v.([]interface{})
+	for _, item := range list {
+		if str, ok := item.(string); ok {
+			s = append(s, str)
+		}
+	}
+	return
+}
+
+// contains Checks if the list at the key holds the string, the list and its strings may be refract 1.0 elements
+func contains(key, s string, child *walker.ObjectWalker) bool {
+	list := elementValue(child.Path(key))
+	if list.Value().Kind() != reflect.Slice {
+		return false
+	}
+
+	items, _ := list.Children()
+	for _, item := range items {
+		if str, ok := elementValue(item).Object().(string); ok && str == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// elementValue Returns the element's content, refract 1.0 wraps the values of meta and attributes into elements,
+// e.g. {"element": "string", "content": "Notes"}. Refract 0.x plain values are returned as is
+func elementValue(el *walker.ObjectWalker) *walker.ObjectWalker {
+	if m, ok := el.Object().(map[string]interface{}); ok {
+		if _, ok := m["element"]; ok {
+			return el.Path("content")
+		}
+	}
+
+	return el
+}
+
+// elementString Returns the string of the plain value or of the refract 1.0 element
+func elementString(el *walker.ObjectWalker) string {
+	return elementValue(el).String()
+}
+
+func inStack(stack []string, name string) bool {
+	for _, s := range stack {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
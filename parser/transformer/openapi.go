@@ -0,0 +1,673 @@
+package transformer
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/definition"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/parser/walker"
+)
+
+// maxRefDepth Limits the number of references followed, protecting against circular references
+const maxRefDepth = 16
+
+// httpMethods Operations supported by a path item, in the order they are documented
+var httpMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+type OpenAPITransformer struct {
+	// doc is the document's root, used to resolve local references
+	doc *walker.ObjectWalker
+}
+
+func NewOpenAPITransformer() Transformer {
+	return new(OpenAPITransformer)
+}
+
+func (tra *OpenAPITransformer) Transform(data interface{}) (def *definition.Api, err error) {
+	el, ok := data.(walker.ObjectWalker)
+	if !ok {
+		err = errors.New("The data's struct given isn't supported by the OpenAPI's Transformer")
+		return
+	}
+
+	if version := specVersion(el.Path("openapi").Object()); !strings.HasPrefix(version, "3.") {
+		err = errors.Errorf("The OpenAPI's version %q given is unsupported", version)
+		return
+	}
+
+	tra.doc = &el
+
+	def = new(definition.Api)
+
+	tra.info(&el, def)
+	tra.servers(&el, def)
+	tra.customTypes(&el, def)
+	tra.securitySchemes(&el, def)
+	tra.securedBy(&el, def)
+	tra.resourceGroups(&el, def)
+
+	return
+}
+
+// info Transforms openapi's info object in api's title and version definition
+func (tra *OpenAPITransformer) info(el *walker.ObjectWalker, def *definition.Api) {
+	def.Title = el.Path("info.title").String()
+	def.Version = stringify(el.Path("info.version").Object())
+}
+
+// servers Transforms openapi's servers definition in api's baseURI, baseURIParameters and protocols definition
+func (tra *OpenAPITransformer) servers(el *walker.ObjectWalker, def *definition.Api) {
+	servers, err := el.Path("servers").Children()
+	if err != nil {
+		return
+	}
+
+	for i, server := range servers {
+		url := server.Path("url").String()
+
+		// The first server is taken as the api's base URI, the others only contribute with their protocols
+		if i == 0 {
+			def.BaseURI = url
+			def.BaseURIParameters = tra.handleServerVariables(server.Path("variables"))
+		}
+
+		if proto, err := definition.NewProtocolFromURL(url); err == nil && !hasProtocol(def.Protocols, proto) {
+			def.Protocols = append(def.Protocols, proto)
+		}
+	}
+}
+
+// customTypes Transforms openapi's components/schemas definition in api's customTypes definition
+func (tra *OpenAPITransformer) customTypes(el *walker.ObjectWalker, def *definition.Api) {
+	def.CustomTypes = tra.handleSchemas(el.Path("components.schemas"))
+}
+
+// securitySchemes Transforms openapi's components/securitySchemes definition in api's securitySchemes definition
+func (tra *OpenAPITransformer) securitySchemes(el *walker.ObjectWalker, def *definition.Api) {
+	def.SecuritySchemes = tra.handleSecuritySchemes(el.Path("components.securitySchemes"))
+}
+
+// securedBy Transforms openapi's security requirements in api's securedBy definition
+func (tra *OpenAPITransformer) securedBy(el *walker.ObjectWalker, def *definition.Api) {
+	def.SecuredBy = tra.handleSecurity(el.Path("security"))
+}
+
+// resourceGroups Groups the openapi's paths by the tag of their operations
+func (tra *OpenAPITransformer) resourceGroups(el *walker.ObjectWalker, def *definition.Api) {
+	var groups []*definition.ResourceGroup
+	index := make(map[string]*definition.ResourceGroup)
+
+	group := func(tag string) *definition.ResourceGroup {
+		if g, ok := index[tag]; ok {
+			return g
+		}
+		g := &definition.ResourceGroup{Title: tag}
+		index[tag] = g
+		groups = append(groups, g)
+		return g
+	}
+
+	// Declared tags keep their order and description
+	if tags, err := el.Path("tags").Children(); err == nil {
+		for _, tag := range tags {
+			group(tag.Path("name").String()).Description = tag.Path("description").String()
+		}
+	}
+
+	paths, err := el.Path("paths").ChildrenMap()
+	if err != nil {
+		return
+	}
+
+	for _, path := range sortedKeys(paths) {
+		// Skips extensions (x-*) declared along with the paths
+		if !strings.HasPrefix(path, "/") {
+			continue
+		}
+
+		item := tra.resolve(paths[path])
+
+		for _, method := range httpMethods {
+			op := item.Path(method)
+			if !op.Value().IsValid() {
+				continue
+			}
+
+			g := group(op.Path("tags").Index(0).String())
+			res := tra.handleResource(g, path, item)
+
+			tra.handlePathParameters(res, op)
+			res.Actions = append(res.Actions, tra.handleOperation(method, path, item, op))
+		}
+	}
+
+	for _, g := range groups {
+		if len(g.Resources) > 0 {
+			def.ResourceGroups = append(def.ResourceGroups, *g)
+		}
+	}
+}
+
+// handleResource Returns the group's resource for the path given, creating it if not yet present
+func (tra *OpenAPITransformer) handleResource(g *definition.ResourceGroup, path string, item *walker.ObjectWalker) *definition.Resource {
+	for i := range g.Resources {
+		if g.Resources[i].Href.Path == path {
+			return &g.Resources[i]
+		}
+	}
+
+	title := item.Path("summary").String()
+	if title == "" {
+		title = path
+	}
+
+	g.Resources = append(g.Resources, definition.Resource{
+		Title:       title,
+		Description: item.Path("description").String(),
+		Href: definition.Href{
+			Path:       path,
+			FullPath:   path,
+			Parameters: tra.handleParameters(item.Path("parameters"), "path"),
+		},
+	})
+
+	return &g.Resources[len(g.Resources)-1]
+}
+
+// handlePathParameters Adds to the resource the path parameters only declared by the operation
+func (tra *OpenAPITransformer) handlePathParameters(res *definition.Resource, op *walker.ObjectWalker) {
+	for _, param := range tra.handleParameters(op.Path("parameters"), "path") {
+		if !hasParameter(res.Href.Parameters, param.Name) {
+			res.Href.Parameters = append(res.Href.Parameters, param)
+		}
+	}
+}
+
+// handleOperation It creates an API's resource action based on the openapi's operation
+func (tra *OpenAPITransformer) handleOperation(method, path string, item, op *walker.ObjectWalker) (action definition.ResourceAction) {
+	action.Title = op.Path("summary").String()
+	action.Description = op.Path("description").String()
+	action.Method = strings.ToUpper(method)
+
+	// Operation's parameters override the ones declared on the path item
+	params := tra.handleParameters(op.Path("parameters"), "query")
+	for _, param := range tra.handleParameters(item.Path("parameters"), "query") {
+		if !hasParameter(params, param.Name) {
+			params = append(params, param)
+		}
+	}
+
+	action.Href = definition.Href{
+		Path:       path,
+		FullPath:   path,
+		Parameters: params,
+	}
+
+	// Inherits the api's security requirements if not present
+	if op.Exists("security") {
+		action.SecuredBy = tra.handleSecurity(op.Path("security"))
+	} else {
+		action.SecuredBy = tra.handleSecurity(tra.doc.Path("security"))
+	}
+
+	action.Transactions = tra.handleTransactions(item, op)
+
+	return
+}
+
+// handleTransactions It holds the responsibility to create multiple transactions based on the operation's request/responses
+func (tra *OpenAPITransformer) handleTransactions(item, op *walker.ObjectWalker) (transactions []definition.Transaction) {
+	req := tra.handleRequest(item, op)
+
+	responses, err := op.Path("responses").ChildrenMap()
+	if err != nil || len(responses) == 0 {
+		if req != nil {
+			transactions = append(transactions, definition.Transaction{Request: *req})
+		}
+		return
+	}
+
+	for _, code := range sortedKeys(responses) {
+		trans := definition.Transaction{
+			Response: tra.handleResponse(code, tra.resolve(responses[code])),
+		}
+
+		if req != nil {
+			trans.Request = *req
+		}
+
+		// Discard the request for the next iterations since it will be duplicated for each transaction-request
+		req = nil
+
+		transactions = append(transactions, trans)
+	}
+
+	return
+}
+
+// handleRequest It creates an API's request based on the operation's header parameters and request body
+func (tra *OpenAPITransformer) handleRequest(item, op *walker.ObjectWalker) (req *definition.Request) {
+	headers := tra.handleHeaderParameters(op.Path("parameters"))
+	for _, header := range tra.handleHeaderParameters(item.Path("parameters")) {
+		if !hasHeader(headers, header.Name) {
+			headers = append(headers, header)
+		}
+	}
+
+	body := tra.resolve(op.Path("requestBody"))
+
+	if len(headers) == 0 && !body.Value().IsValid() {
+		return
+	}
+
+	return &definition.Request{
+		Description: body.Path("description").String(),
+		Headers:     headers,
+		Body:        tra.handleContent(body.Path("content")),
+	}
+}
+
+// handleResponse It creates an API's response based on the openapi's response
+func (tra *OpenAPITransformer) handleResponse(code string, el *walker.ObjectWalker) (resp definition.Response) {
+	// Non numeric codes (default, 2XX) are kept as 0 as there is no numeric representation for them
+	resp.StatusCode, _ = strconv.Atoi(code)
+	resp.Description = el.Path("description").String()
+	resp.Headers = tra.handleHeaders(el.Path("headers"))
+	resp.Body = tra.handleContent(el.Path("content"))
+
+	return
+}
+
+// handleContent Generic method which handles openapi's content (media type -> media type object) definition.
+func (tra *OpenAPITransformer) handleContent(el *walker.ObjectWalker) (bodies []definition.Body) {
+	content, err := el.ChildrenMap()
+	if err != nil {
+		return
+	}
+
+	for _, mediaType := range sortedKeys(content) {
+		media := content[mediaType]
+		schema := media.Path("schema")
+
+		body := definition.Body{
+			MediaType: definition.MediaType(mediaType),
+			Example:   tra.handleExample(media),
+		}
+
+		if body.Example == "" {
+			body.Example = stringify(tra.resolve(schema).Path("example").Object())
+		}
+
+		// Inline objects are not an api's CustomType, so their properties are kept within the body
+		if !schema.Exists("$ref") && tra.resolve(schema).Exists("properties") {
+			body.CustomType = &definition.CustomType{
+				Type:       tra.schemaType(schema),
+				Properties: tra.handleProperties(schema, nil),
+			}
+		} else {
+			body.Type = tra.schemaType(schema)
+		}
+
+		bodies = append(bodies, body)
+	}
+
+	return
+}
+
+// handleExample Returns the example of a media type, taking the first of the named examples if no example is given
+func (tra *OpenAPITransformer) handleExample(el *walker.ObjectWalker) string {
+	if el.Exists("example") {
+		return stringify(el.Path("example").Object())
+	}
+
+	examples, err := el.Path("examples").ChildrenMap()
+	if err != nil || len(examples) == 0 {
+		return ""
+	}
+
+	return stringify(tra.resolve(examples[sortedKeys(examples)[0]]).Path("value").Object())
+}
+
+// handleSchemas Generic method which handles openapi's schemas definition.
+func (tra *OpenAPITransformer) handleSchemas(el *walker.ObjectWalker) (customTypes []definition.CustomType) {
+	schemas, err := el.ChildrenMap()
+	if err != nil {
+		return
+	}
+
+	for _, name := range sortedKeys(schemas) {
+		schema := schemas[name]
+
+		customType := definition.CustomType{
+			Name:        name,
+			Description: schema.Path("description").String(),
+			Type:        tra.schemaType(schema),
+			Default:     schema.Path("default").Object(),
+			Enum:        schema.Path("enum").Object(),
+			Properties:  tra.handleProperties(schema, []string{"#/components/schemas/" + name}),
+		}
+
+		if schema.Exists("example") {
+			customType.Examples = append(customType.Examples, schema.Path("example").Object())
+		}
+
+		customTypes = append(customTypes, customType)
+	}
+
+	return
+}
+
+// handleProperties It transforms the schema's properties, including the ones composed by allOf, into an API's array of definition.property.
+// The stack holds the references being resolved, a schema composed of itself (e.g. a tree's node) is only expanded once
+func (tra *OpenAPITransformer) handleProperties(el *walker.ObjectWalker, stack []string) (props []definition.CustomTypeProperty) {
+	if ref := el.Path("$ref").String(); ref != "" {
+		if inStack(stack, ref) {
+			return
+		}
+		stack = append(stack, ref)
+	}
+
+	schema := tra.resolve(el)
+
+	if members, err := schema.Path("allOf").Children(); err == nil {
+		for _, member := range members {
+			props = append(props, tra.handleProperties(member, stack)...)
+		}
+	}
+
+	properties, err := schema.Path("properties").ChildrenMap()
+	if err != nil {
+		return
+	}
+
+	for _, name := range sortedKeys(properties) {
+		property := properties[name]
+		resolved := tra.resolve(property)
+
+		prop := definition.CustomTypeProperty{
+			Name:        name,
+			Type:        tra.schemaType(property),
+			Required:    contains("required", name, schema),
+			Description: resolved.Path("description").String(),
+			Example:     stringify(resolved.Path("example").Object()),
+		}
+
+		// Only inline objects are expanded, references are available as custom types
+		if !property.Exists("$ref") {
+			if resolved.Exists("properties") {
+				prop.Properties = tra.handleProperties(resolved, stack)
+			} else if items := resolved.Path("items"); !items.Exists("$ref") && items.Exists("properties") {
+				prop.Properties = tra.handleProperties(items, stack)
+			}
+		}
+
+		props = append(props, prop)
+	}
+
+	return
+}
+
+// handleParameters Generic method which handles openapi's parameters located in the place given (path, query).
+func (tra *OpenAPITransformer) handleParameters(el *walker.ObjectWalker, in string) (params []definition.Parameter) {
+	children, err := el.Children()
+	if err != nil {
+		return
+	}
+
+	for _, child := range children {
+		child = tra.resolve(child)
+		if child.Path("in").String() != in {
+			continue
+		}
+
+		schema := tra.resolve(child.Path("schema"))
+
+		param := definition.Parameter{
+			Name:        child.Path("name").String(),
+			Description: child.Path("description").String(),
+			Type:        tra.schemaType(child.Path("schema")),
+			// Path parameters are always required
+			Required:  in == "path" || child.Path("required").Object() == true,
+			Pattern:   stringPtr(schema.Path("pattern")),
+			MinLength: intPtr(schema.Path("minLength")),
+			MaxLength: intPtr(schema.Path("maxLength")),
+			Min:       floatPtr(schema.Path("minimum")),
+			Max:       floatPtr(schema.Path("maximum")),
+			Example:   child.Path("example").Object(),
+		}
+
+		if param.Example == nil {
+			param.Example = schema.Path("example").Object()
+		}
+
+		params = append(params, param)
+	}
+
+	return
+}
+
+// handleHeaderParameters Generic method which handles openapi's parameters located in the headers.
+func (tra *OpenAPITransformer) handleHeaderParameters(el *walker.ObjectWalker) (headers []definition.Header) {
+	for _, param := range tra.handleParameters(el, "header") {
+		headers = append(headers, definition.Header{
+			Name:        param.Name,
+			Description: param.Description,
+			Example:     param.Example,
+		})
+	}
+
+	return
+}
+
+// handleHeaders Generic method which handles openapi's headers (name -> header object) definition.
+func (tra *OpenAPITransformer) handleHeaders(el *walker.ObjectWalker) (headers []definition.Header) {
+	children, err := el.ChildrenMap()
+	if err != nil {
+		return
+	}
+
+	for _, name := range sortedKeys(children) {
+		header := tra.resolve(children[name])
+
+		example := header.Path("example").Object()
+		if example == nil {
+			example = tra.resolve(header.Path("schema")).Path("example").Object()
+		}
+
+		headers = append(headers, definition.Header{
+			Name:        name,
+			Description: header.Path("description").String(),
+			Example:     example,
+		})
+	}
+
+	return
+}
+
+// handleServerVariables Generic method which handles the server's variables definition.
+func (tra *OpenAPITransformer) handleServerVariables(el *walker.ObjectWalker) (params []definition.Parameter) {
+	variables, err := el.ChildrenMap()
+	if err != nil {
+		return
+	}
+
+	for _, name := range sortedKeys(variables) {
+		variable := variables[name]
+
+		params = append(params, definition.Parameter{
+			Name:        name,
+			Description: variable.Path("description").String(),
+			Type:        "string",
+			Required:    true,
+			Example:     variable.Path("default").Object(),
+		})
+	}
+
+	return
+}
+
+// handleSecuritySchemes Generic method which handles openapi's security schemes definition.
+func (tra *OpenAPITransformer) handleSecuritySchemes(el *walker.ObjectWalker) (schemes []definition.SecurityScheme) {
+	children, err := el.ChildrenMap()
+	if err != nil {
+		return
+	}
+
+	for _, name := range sortedKeys(children) {
+		child := tra.resolve(children[name])
+
+		scheme := definition.SecurityScheme{
+			Name:        name,
+			Type:        child.Path("type").String(),
+			Description: child.Path("description").String(),
+		}
+
+		settings, _ := child.ChildrenMap()
+		for _, k := range sortedKeys(settings) {
+			if k == "type" || k == "description" {
+				continue
+			}
+
+			scheme.Settings = append(scheme.Settings, definition.SecuritySchemeSetting{
+				Name: k,
+				Data: settings[k].Object(),
+			})
+		}
+
+		schemes = append(schemes, scheme)
+	}
+
+	return
+}
+
+// handleSecurity Generic method which handles openapi's security requirements definition.
+func (tra *OpenAPITransformer) handleSecurity(el *walker.ObjectWalker) (opts []definition.Option) {
+	requirements, err := el.Children()
+	if err != nil {
+		return
+	}
+
+	for _, requirement := range requirements {
+		schemes, err := requirement.ChildrenMap()
+		if err != nil {
+			continue
+		}
+
+		for _, name := range sortedKeys(schemes) {
+			opts = append(opts, definition.Option{
+				Name:       name,
+				Parameters: map[string]interface{}{"scopes": schemes[name].Object()},
+			})
+		}
+	}
+
+	return
+}
+
+// schemaType Returns the type's name of a schema, using the custom type's name for references and RAML's notation for arrays and unions
+func (tra *OpenAPITransformer) schemaType(el *walker.ObjectWalker) string {
+	if el.Exists("$ref") {
+		return refName(el.Path("$ref").String())
+	}
+
+	if members, err := el.Path("allOf").Children(); err == nil && len(members) == 1 {
+		return tra.schemaType(members[0])
+	}
+
+	for _, key := range []string{"oneOf", "anyOf"} {
+		if members, err := el.Path(key).Children(); err == nil {
+			var names []string
+			for _, member := range members {
+				names = append(names, tra.schemaType(member))
+			}
+			return strings.Join(names, " | ")
+		}
+	}
+
+	switch t := el.Path("type").String(); {
+	case t == "array":
+		return tra.schemaType(el.Path("items")) + "[]"
+	case t != "":
+		return t
+	case el.Exists("properties") || el.Exists("allOf"):
+		return "object"
+	}
+
+	return ""
+}
+
+// resolve Follows the local references ($ref) of the element given
+func (tra *OpenAPITransformer) resolve(el *walker.ObjectWalker) *walker.ObjectWalker {
+	for i := 0; i < maxRefDepth && el.Exists("$ref"); i++ {
+		ref := el.Path("$ref").String()
+		if !strings.HasPrefix(ref, "#/") {
+			break
+		}
+
+		el = tra.doc.Path(strings.Replace(strings.TrimPrefix(ref, "#/"), "/", ".", -1))
+	}
+
+	return el
+}
+
+//generic helper methods, unbound to struct
+
+// sortedKeys Returns the keys of the children sorted, so the output doesn't depend on the map's order
+func sortedKeys(children map[string]*walker.ObjectWalker) (keys []string) {
+	for k := range children {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return
+}
+
+// specVersion Returns the document's version, the versions written unquoted (e.g. swagger: 2.0) are decoded as numbers
+func specVersion(v interface{}) string {
+	var n float64
+	switch val := v.(type) {
+	case float64:
+		n = val
+	case int:
+		n = float64(val)
+	default:
+		return stringify(v)
+	}
+
+	version := strconv.FormatFloat(n, 'f', -1, 64)
+	if !strings.Contains(version, ".") {
+		version += ".0"
+	}
+
+	return version
+}
+
+func hasProtocol(protos []definition.Protocol, proto definition.Protocol) bool {
+	for _, p := range protos {
+		if strings.EqualFold(string(p), string(proto)) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasParameter(params []definition.Parameter, name string) bool {
+	for _, p := range params {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasHeader(headers []definition.Header, name string) bool {
+	for _, h := range headers {
+		if strings.EqualFold(h.Name, name) {
+			return true
+		}
+	}
+	return false
+}
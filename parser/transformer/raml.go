@@ -1,6 +1,8 @@
 package transformer
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"sort"
@@ -9,21 +11,40 @@ import (
 
 	"github.com/Jumpscale/go-raml/raml"
 	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/definition"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/parser/walker"
 )
 
-type RamlTransformer struct{}
+type RamlTransformer struct {
+	// ramlDef is the definition transformed, used to look up the resource types and traits applied
+	ramlDef raml.APIDefinition
+}
 
 func NewRamlTransformer() Transformer {
 	return new(RamlTransformer)
 }
 
 func (tra *RamlTransformer) Transform(data interface{}) (def *definition.Api, err error) {
-	ramlDef, ok := data.(raml.APIDefinition)
-	if !ok {
+	var ramlDef raml.APIDefinition
+
+	switch d := data.(type) {
+	case raml.APIDefinition:
+		ramlDef = d
+	case walker.ObjectWalker:
+		// RAML 0.8's documents aren't supported by go-raml, so they are given as a generic document
+		doc, ok := d.Object().(map[string]interface{})
+		if !ok {
+			err = errors.New("The RAML 0.8's document given is empty")
+			return
+		}
+
+		ramlDef = upgradeRaml08(doc)
+	default:
 		err = errors.New("The data's struct given isn't supported by the RAML's Transformer")
 		return
 	}
 
+	tra.ramlDef = ramlDef
+
 	def = new(definition.Api)
 
 	tra.title(ramlDef, def)
@@ -32,12 +53,15 @@ func (tra *RamlTransformer) Transform(data interface{}) (def *definition.Api, er
 	tra.baseURIParameters(ramlDef, def)
 	tra.protocols(ramlDef, def)
 	tra.mediaType(ramlDef, def)
+	tra.documentation(ramlDef, def)
 	tra.customTypes(ramlDef, def)
 	tra.securitySchemes(ramlDef, def)
 	tra.securedBy(ramlDef, def)
 	tra.traits(ramlDef.Traits, def)
 	tra.libraries(ramlDef.Libraries, def)
 
+	def.Annotations = tra.handleAnnotations(ramlDef.Annotations)
+
 	err = tra.resourceGroups(ramlDef, def)
 
 	return
@@ -75,6 +99,13 @@ func (tra *RamlTransformer) mediaType(ramlDef raml.APIDefinition, def *definitio
 	}
 }
 
+// documentation Transforms raml's documentation definition in api's documentation definition
+func (tra *RamlTransformer) documentation(ramlDef raml.APIDefinition, def *definition.Api) {
+	for _, doc := range ramlDef.Documentation {
+		def.Documentation = append(def.Documentation, definition.Section{Title: doc.Title, Content: doc.Content})
+	}
+}
+
 // customTypes Transforms raml's customTypes definition in api's customTypes definition
 func (tra *RamlTransformer) customTypes(ramlDef raml.APIDefinition, def *definition.Api) {
 	def.CustomTypes = tra.handleTypes(ramlDef.Types)
@@ -139,7 +170,7 @@ func (tra *RamlTransformer) handleOptions(ramlOpts []raml.DefinitionChoice) (opt
 		opt.Name = tra.removeLibraryName(ramlOpt.Name)
 
 		if ramlOpt.Parameters != nil {
-			opt.Parameters = ramlOpt.Parameters
+			opt.Parameters, _ = normalizeValue(ramlOpt.Parameters).(map[string]interface{})
 		}
 
 		opts = append(opts, *opt)
@@ -150,34 +181,175 @@ func (tra *RamlTransformer) handleOptions(ramlOpts []raml.DefinitionChoice) (opt
 
 // handleParameters Generic method which handles raml's parameter definition.
 func (tra *RamlTransformer) handleParameters(ramlParams map[string]raml.NamedParameter) (params []definition.Parameter) {
-	var sortedRamlParams []string
+	for _, name := range sortedParameters(ramlParams) {
+		params = append(params, tra.handleParameter(name, ramlParams[name]))
+	}
+
+	return
+}
+
+// handleParameter It transforms the raml's parameter declared with the key given
+func (tra *RamlTransformer) handleParameter(name string, ramlParam raml.NamedParameter) definition.Parameter {
+	param := new(definition.Parameter)
+
+	// It takes the parameter name over the parameter key from raml definition
+	if param.Name = name; ramlParam.Name != "" {
+		param.Name = ramlParam.Name
+	}
+
+	param.Description = ramlParam.Description
+	param.Type = tra.removeLibraryName(ramlParam.Type)
+	param.Required = ramlParam.Required
+	param.Pattern = ramlParam.Pattern
+	param.MinLength = ramlParam.MinLength
+	param.MaxLength = ramlParam.MaxLength
+	param.Min = ramlParam.Minimum
+	param.Max = ramlParam.Maximum
+	param.Example = normalizeValue(ramlParam.Example)
+	if ramlParam.Enum != nil {
+		param.Enum = normalizeValue(ramlParam.Enum).([]interface{})
+	}
+	param.Default = normalizeValue(ramlParam.Default)
+	param.Format = ramlParam.Format
+	param.Annotations = tra.handleAnnotations(ramlParam.Annotations)
+
+	// The parameters typed by custom types take the type and the facets they inherit
+	if ramlType, ok := tra.customType(ramlParam.Type); ok {
+		tra.handleParameterType(param, ramlType, []string{ramlParam.Type})
+	}
+
+	return *param
+}
+
+// sortedParameters Returns the parameters' keys sorted
+func sortedParameters(ramlParams map[string]raml.NamedParameter) (names []string) {
 	for k := range ramlParams {
-		sortedRamlParams = append(sortedRamlParams, k)
+		names = append(names, k)
 	}
 
-	sort.Strings(sortedRamlParams)
+	sort.Strings(names)
 
-	for _, name := range sortedRamlParams {
-		ramlParam := ramlParams[name]
+	return
+}
 
-		param := new(definition.Parameter)
+// handleQueryParameters It transforms the method's query parameters and query string into an API's array of definition.parameter,
+// the parameters typed by object types are expanded into their properties
+func (tra *RamlTransformer) handleQueryParameters(ramlMethod *raml.Method) (params []definition.Parameter) {
+	ramlParams := make(map[string]raml.NamedParameter, len(ramlMethod.QueryParameters)+len(ramlMethod.QueryString))
+	for name, param := range ramlMethod.QueryParameters {
+		ramlParams[name] = param
+	}
+	for name, param := range ramlMethod.QueryString {
+		ramlParams[name] = param
+	}
 
-		// It takes the parameter name over the parameter key from raml definition
-		if param.Name = name; ramlParam.Name != "" {
-			param.Name = ramlParam.Name
+	// The raml's parameters are looked up by their keys, their names can be given apart
+	for _, key := range sortedParameters(ramlParams) {
+		ramlParam := ramlParams[key]
+		param := tra.handleParameter(key, ramlParam)
+
+		ramlType, ok := tra.customType(ramlParam.Type)
+		if !ok {
+			params = append(params, param)
+			continue
 		}
 
-		param.Description = ramlParam.Description
-		param.Type = tra.removeLibraryName(ramlParam.Type)
-		param.Required = ramlParam.Required
-		param.Pattern = ramlParam.Pattern
-		param.MinLength = ramlParam.MinLength
-		param.MaxLength = ramlParam.MaxLength
-		param.Min = ramlParam.Minimum
-		param.Max = ramlParam.Maximum
-		param.Example = ramlParam.Example
+		resolved := tra.resolveType(ramlType, []string{ramlParam.Type})
+		if resolved.Kind != definition.ObjectKind {
+			params = append(params, param)
+			continue
+		}
 
-		params = append(params, *param)
+		for _, prop := range resolved.Properties {
+			params = append(params, definition.Parameter{
+				Name:        prop.Name,
+				Description: prop.Description,
+				Type:        prop.Type,
+				Required:    prop.Required,
+				Pattern:     prop.Pattern,
+				MinLength:   prop.MinLength,
+				MaxLength:   prop.MaxLength,
+				Min:         prop.Min,
+				Max:         prop.Max,
+				Enum:        prop.Enum,
+				Default:     normalizeValue(prop.Default),
+				Format:      prop.Format,
+			})
+		}
+	}
+
+	return
+}
+
+// handleParameterType It resolves the parameter's custom type into the built-in type it derives from,
+// the facets not declared by the parameter are inherited from the custom types
+func (tra *RamlTransformer) handleParameterType(param *definition.Parameter, ramlType raml.Type, stack []string) {
+	if param.Description == "" {
+		param.Description = ramlType.Description
+	}
+	if param.Pattern == nil {
+		param.Pattern = ramlType.Pattern
+	}
+	if param.MinLength == nil {
+		param.MinLength = ramlType.MinLength
+	}
+	if param.MaxLength == nil {
+		param.MaxLength = ramlType.MaxLength
+	}
+	if param.Min == nil {
+		param.Min = ramlType.Minimum
+	}
+	if param.Max == nil {
+		param.Max = ramlType.Maximum
+	}
+	if param.Enum == nil {
+		param.Enum, _ = normalizeValue(ramlType.Enum).([]interface{})
+	}
+	if param.Default == nil {
+		param.Default = normalizeValue(ramlType.Default)
+	}
+	if param.Format == "" && ramlType.Format != nil {
+		param.Format = *ramlType.Format
+	}
+	if param.Example == nil {
+		param.Example = normalizeValue(ramlType.Example)
+	}
+
+	parent, _ := ramlType.Type.(string)
+	parentType, ok := tra.customType(parent)
+
+	switch {
+	case ok && !inStack(stack, parent):
+		tra.handleParameterType(param, parentType, append(stack, parent))
+	case isScalarType(parent):
+		param.Type = parent
+	}
+}
+
+// handleAnnotations Generic method which handles raml's annotations, described by their annotation types if declared.
+func (tra *RamlTransformer) handleAnnotations(ramlAnnotations map[string]interface{}) (annotations definition.Annotations) {
+	var sortedAnnotations []string
+	for k := range ramlAnnotations {
+		sortedAnnotations = append(sortedAnnotations, k)
+	}
+
+	sort.Strings(sortedAnnotations)
+
+	for _, key := range sortedAnnotations {
+		// The annotations are applied by their name in parentheses, e.g. (deprecated) or (lib.deprecated)
+		name := strings.TrimSuffix(strings.TrimPrefix(key, "("), ")")
+
+		annotation := definition.Annotation{
+			Name:  tra.removeLibraryName(name),
+			Value: normalizeValue(ramlAnnotations[key]),
+		}
+
+		if annotationType, ok := tra.annotationType(name); ok {
+			annotation.DisplayName = annotationType.DisplayName
+			annotation.Description = annotationType.Description
+		}
+
+		annotations = append(annotations, annotation)
 	}
 
 	return
@@ -206,7 +378,7 @@ func (tra *RamlTransformer) handleHeaders(ramlHeaders map[raml.HTTPHeader]raml.H
 		}
 
 		header.Description = ramlHead.Description
-		header.Example = ramlHead.Example
+		header.Example = normalizeValue(ramlHead.Example)
 
 		headers = append(headers, *header)
 	}
@@ -228,9 +400,9 @@ func (tra *RamlTransformer) handleTypes(ramlTypes map[string]raml.Type) (customT
 		customType := &definition.CustomType{
 			Name:        name,
 			Description: ramlType.Description,
-			Type:        ramlType.Type,
-			Enum:        ramlType.Enum,
-			Default:     ramlType.Default,
+			Type:        normalizeValue(ramlType.Type),
+			Enum:        normalizeValue(ramlType.Enum),
+			Default:     normalizeValue(ramlType.Default),
 		}
 
 		// We need to remove the library name space from the type
@@ -238,16 +410,32 @@ func (tra *RamlTransformer) handleTypes(ramlTypes map[string]raml.Type) (customT
 			customType.Type = tra.removeLibraryName(t)
 		}
 
-		customType.Properties = tra.handleCustomTypeProperties(ramlType.Properties)
+		// The properties inherited are merged with the type's properties
+		resolved := tra.resolveType(ramlType, []string{name})
+		customType.Properties = resolved.Properties
+		customType.Kind = resolved.Kind
+		customType.Parents = resolved.Parents
+		customType.Items = resolved.Items
+		customType.Members = resolved.Members
+		customType.Annotations = tra.handleAnnotations(ramlType.Annotations)
+
+		// The named examples are sorted by their names, so the examples keep the same order between runs
+		var examples []string
+		for k := range ramlType.Examples {
+			examples = append(examples, k)
+		}
+		sort.Strings(examples)
 
-		for _, e := range ramlType.Examples {
-			customType.Examples = append(customType.Examples, e)
+		for _, k := range examples {
+			customType.Examples = append(customType.Examples, normalizeValue(ramlType.Examples[k]))
 		}
 
 		if ramlType.Example != nil {
-			customType.Examples = append(customType.Examples, ramlType.Example)
+			customType.Examples = append(customType.Examples, normalizeValue(ramlType.Example))
 		}
 
+		customType.NamedExamples = tra.handleExamples(ramlType.Examples, ramlType.Example, tra.ramlDef.MediaType)
+
 		// It takes the parameter name over the parameter key from raml definition
 		if ramlType.DisplayName != "" {
 			customType.Name = ramlType.DisplayName
@@ -320,7 +508,7 @@ func (tra *RamlTransformer) handleSecuritySchemes(ramlSchemes map[string]raml.Se
 		for _, k := range sortedRamlSchemesSets {
 			scheme.Settings = append(scheme.Settings, definition.SecuritySchemeSetting{
 				Name: k,
-				Data: ramlScheme.Settings[k],
+				Data: normalizeValue(ramlScheme.Settings[k]),
 			})
 		}
 
@@ -377,6 +565,8 @@ func (tra *RamlTransformer) handleResources(ramlResources interface{}, parent *d
 
 // handleResource Generic method which handles raml's resource definition.
 func (tra *RamlTransformer) handleResource(ramlRes raml.Resource, parent *definition.Resource) definition.Resource {
+	ramlRes = tra.applyResourceType(ramlRes, parent.Href.FullPath)
+
 	return definition.Resource{
 		Title:       ramlRes.DisplayName,
 		Description: ramlRes.Description,
@@ -385,23 +575,28 @@ func (tra *RamlTransformer) handleResource(ramlRes raml.Resource, parent *defini
 			Path:       ramlRes.URI,
 			Parameters: tra.handleParameters(ramlRes.URIParameters),
 		},
-		Is:        tra.handleOptions(ramlRes.Is),
-		SecuredBy: tra.handleOptions(ramlRes.SecuredBy),
-		Actions:   tra.handleResourceMethods(ramlRes, ramlRes.Methods),
+		Is:          tra.handleOptions(ramlRes.Is),
+		SecuredBy:   tra.handleOptions(ramlRes.SecuredBy),
+		Actions:     tra.handleResourceMethods(ramlRes, ramlRes.Methods, parent.Href.FullPath),
+		Annotations: tra.handleAnnotations(ramlRes.Annotations),
 	}
 }
 
 // handleResource Generic method which handles raml's method definition.
-func (tra *RamlTransformer) handleResourceMethods(ramlRes raml.Resource, ramlMethods []*raml.Method) (actions []definition.ResourceAction) {
+func (tra *RamlTransformer) handleResourceMethods(ramlRes raml.Resource, ramlMethods []*raml.Method, parent string) (actions []definition.ResourceAction) {
 	for _, ramlMethod := range ramlMethods {
+		// The traits are applied so the action shows its effective contract
+		ramlMethod = tra.applyTraits(ramlRes, ramlMethod, parent)
+
 		action := new(definition.ResourceAction)
 
 		action.Title = ramlMethod.DisplayName
 		action.Description = ramlMethod.Description
 		action.Href = definition.Href{
-			Parameters: tra.handleParameters(ramlMethod.QueryParameters),
+			Parameters: tra.handleQueryParameters(ramlMethod),
 		}
 		action.Is = tra.handleOptions(ramlMethod.Is)
+		action.Annotations = tra.handleAnnotations(ramlMethod.Annotations)
 
 		// Inherits securedBy options from the parent securedBy if not present
 		action.SecuredBy = tra.handleOptions(ramlMethod.SecuredBy)
@@ -442,55 +637,235 @@ func (tra *RamlTransformer) handleResponse(code string, ramlResp raml.Response)
 	return
 }
 
-// handleBodies Generic method which handles raml's bodies definition.
+// handleBodies Generic method which handles raml's bodies definition, a body is created for each media type declared.
 func (tra *RamlTransformer) handleBodies(ramlBodies *raml.Bodies) (bodies []definition.Body) {
-	if ramlBodies == nil || (ramlBodies.ApplicationJSON == nil && ramlBodies.Type == "") {
+	if ramlBodies == nil {
 		return
 	}
 
-	body := new(definition.Body)
-
 	if ramlBodies.ApplicationJSON != nil {
-		body.MediaType = definition.MediaType("application/json")
+		// The description and example are only given by the media type's body, e.g. RAML 0.8's upgraded bodies
+		jsonBody := ramlBodies.ForMIMEType["application/json"]
+		body := definition.Body{
+			MediaType:   definition.MediaType("application/json"),
+			Description: jsonBody.Description,
+			Example:     jsonBody.Example,
+		}
 
 		// t will be the body's type
 		bodyType := tra.removeLibraryName(ramlBodies.ApplicationJSON.TypeString())
 
 		// If properties is empty then it is not a api's CustomType
 		if ramlBodies.ApplicationJSON.Properties != nil {
-			customType := &definition.CustomType{
-				Type: bodyType,
+			body.CustomType = &definition.CustomType{
+				Type:       bodyType,
+				Properties: tra.handleCustomTypeProperties(ramlBodies.ApplicationJSON.Properties),
 			}
+		} else {
+			body.Type = bodyType
+		}
+
+		bodies = append(bodies, body)
+	}
+
+	var sortedMediaTypes []string
+	for k := range ramlBodies.ForMIMEType {
+		// The application/json's body is already handled
+		if k != "application/json" || ramlBodies.ApplicationJSON == nil {
+			sortedMediaTypes = append(sortedMediaTypes, k)
+		}
+	}
+
+	sort.Strings(sortedMediaTypes)
+
+	for _, mediaType := range sortedMediaTypes {
+		bodies = append(bodies, tra.handleBody(definition.MediaType(mediaType), ramlBodies.ForMIMEType[mediaType]))
+	}
+
+	// The body declared without media type uses the api's media type
+	if len(bodies) == 0 && ramlBodies.Type != "" {
+		bodies = append(bodies, definition.Body{
+			Type:        tra.removeLibraryName(ramlBodies.Type),
+			MediaType:   definition.MediaType(tra.ramlDef.MediaType),
+			Description: ramlBodies.Description,
+			Example:     ramlBodies.Example,
+		})
+	}
+
+	for i := range bodies {
+		tra.handleBodyExamples(&bodies[i])
+	}
+
+	return
+}
+
+// handleBodyExamples Collects the examples of the body and its type, the first one is kept as the body's example if none is given
+func (tra *RamlTransformer) handleBodyExamples(body *definition.Body) {
+	mediaType := string(body.MediaType)
 
-			if ramlBodies.ApplicationJSON.Properties != nil {
-				customType.Properties = tra.handleCustomTypeProperties(ramlBodies.ApplicationJSON.Properties)
+	if body.Example != "" {
+		body.NamedExamples = append(body.NamedExamples, tra.handleExample("", body.Example, mediaType))
+	}
+
+	if ramlType, ok := tra.customType(definition.CleanCustomTypeName(body.Type)); ok {
+		body.NamedExamples = append(body.NamedExamples, tra.handleExamples(ramlType.Examples, ramlType.Example, mediaType)...)
+	}
+
+	if body.Example == "" && len(body.NamedExamples) > 0 {
+		body.Example = body.NamedExamples[0].Content
+	}
+}
+
+// handleExamples Generic method which handles raml's named examples and the single example, sorted by their names.
+func (tra *RamlTransformer) handleExamples(ramlExamples map[string]interface{}, ramlExample interface{}, mediaType string) (examples []definition.Example) {
+	var sortedExamples []string
+	for k := range ramlExamples {
+		sortedExamples = append(sortedExamples, k)
+	}
+
+	sort.Strings(sortedExamples)
+
+	for _, name := range sortedExamples {
+		examples = append(examples, tra.handleExample(name, ramlExamples[name], mediaType))
+	}
+
+	if ramlExample != nil {
+		examples = append(examples, tra.handleExample("", ramlExample, mediaType))
+	}
+
+	return
+}
+
+// handleExample Generic method which handles raml's example, its value is pretty-printed for the media type.
+// The examples declaring their facets (displayName, description, strict) hold the example in their value
+func (tra *RamlTransformer) handleExample(name string, ramlExample interface{}, mediaType string) definition.Example {
+	example := definition.Example{
+		Name:   name,
+		Strict: true,
+		Value:  normalizeValue(ramlExample),
+	}
+
+	if m, ok := example.Value.(map[string]interface{}); ok && isExampleFacets(m) {
+		if value, ok := m["value"]; ok {
+			example.Value = value
+			example.DisplayName = stringify(m["displayName"])
+			example.Description = stringify(m["description"])
+
+			if strict, ok := m["strict"].(bool); ok {
+				example.Strict = strict
 			}
+		}
+	}
 
-			body.CustomType = customType
+	example.Content = formatExample(example.Value, mediaType)
 
-		} else {
-			body.Type = bodyType
+	return example
+}
+
+// isExampleFacets Tells if the example's map declares the example's facets, its keys are value and the facets or annotations.
+// e.g. {value: 10, currency: EUR} is a payload, not an example's declaration
+func isExampleFacets(m map[string]interface{}) bool {
+	if _, ok := m["value"]; !ok {
+		return false
+	}
+
+	for k := range m {
+		switch {
+		case k == "value", k == "displayName", k == "description", k == "strict":
+		case strings.HasPrefix(k, "(") && strings.HasSuffix(k, ")"):
+		default:
+			return false
 		}
+	}
 
-	} else {
+	return true
+}
 
-		//@todo Add here your code to support other media types for bodies
+// annotationType Returns the annotation type declared with the name given, the libraries' ones are prefixed by the library's name
+func (tra *RamlTransformer) annotationType(name string) (annotationType raml.AnnotationType, ok bool) {
+	if annotationType, ok = tra.ramlDef.AnnotationTypes[name]; ok {
+		return
+	}
 
-		body.Type = tra.removeLibraryName(ramlBodies.Type)
-		body.Description = ramlBodies.Description
-		body.Example = ramlBodies.Example
+	if lib, local := tra.library(name); lib != nil {
+		annotationType, ok = lib.AnnotationTypes[local]
+	}
 
-		if ramlBodies.Example != "" {
-			body.Example = ramlBodies.Example
+	return
+}
+
+// customType Returns the raml's type declared with the name given, the libraries' ones are prefixed by the library's name
+func (tra *RamlTransformer) customType(name string) (ramlType raml.Type, ok bool) {
+	if ramlType, ok = tra.ramlDef.Types[name]; ok {
+		return
+	}
+
+	if lib, local := tra.library(name); lib != nil {
+		ramlType, ok = lib.Types[local]
+	}
+
+	return
+}
+
+// handleBody Generic method which handles raml's body definition of a media type.
+func (tra *RamlTransformer) handleBody(mediaType definition.MediaType, ramlBody raml.Body) (body definition.Body) {
+	body.MediaType = mediaType
+	body.Description = ramlBody.Description
+	body.Example = ramlBody.Example
+
+	bodyType := tra.removeLibraryName(ramlBody.Type)
+
+	// Bodies with properties or form parameters (e.g. multipart/form-data) are described by an api's CustomType
+	if ramlBody.Properties == nil && ramlBody.FormParameters == nil {
+		body.Type = bodyType
+		return
+	}
+
+	if bodyType == "" {
+		bodyType = "object"
+	}
+
+	body.CustomType = &definition.CustomType{
+		Type:       bodyType,
+		Properties: append(tra.handleCustomTypeProperties(ramlBody.Properties), tra.handleFormParameters(ramlBody.FormParameters)...),
+	}
+
+	return
+}
+
+// handleFormParameters It transforms RAML's form parameters into an API's array of definition.property
+func (tra *RamlTransformer) handleFormParameters(ramlParams map[string]raml.NamedParameter) (props []definition.CustomTypeProperty) {
+	for _, param := range tra.handleParameters(ramlParams) {
+		prop := definition.CustomTypeProperty{
+			Name:        param.Name,
+			Type:        param.Type,
+			Required:    param.Required,
+			Description: param.Description,
+		}
+
+		prop.Pattern = param.Pattern
+		prop.MinLength = param.MinLength
+		prop.MaxLength = param.MaxLength
+		prop.Min = param.Min
+		prop.Max = param.Max
+
+		if param.Example != nil {
+			prop.Example = fmt.Sprint(param.Example)
 		}
+
+		props = append(props, prop)
 	}
 
-	bodies = append(bodies, *body)
 	return
 }
 
 // handleCustomTypeProperties It transforms RAML's custom properties into an API's array of definition.property
 func (tra *RamlTransformer) handleCustomTypeProperties(properties map[string]interface{}) (props []definition.CustomTypeProperty) {
+	return tra.customTypeProperties(properties, nil)
+}
+
+// customTypeProperties It transforms RAML's custom properties, the stack holds the types being resolved
+func (tra *RamlTransformer) customTypeProperties(properties map[string]interface{}, stack []string) (props []definition.CustomTypeProperty) {
 	var sortedProps []string
 	for k := range properties {
 		sortedProps = append(sortedProps, k)
@@ -507,23 +882,38 @@ func (tra *RamlTransformer) handleCustomTypeProperties(properties map[string]int
 			for k, v := range val {
 				switch k {
 				case "type":
-					p.Type = tra.removeLibraryName(v.(string))
+					if name, ok := v.(string); ok {
+						p.Type = tra.removeLibraryName(name)
+						break
+					}
+
+					// Multiple inheritance or inline declaration, e.g. type: [Base, Audited]
+					ct := tra.resolveType(inlineType(val), stack)
+					p.Type = propertyType(ct)
+					p.Properties = mergeProperties(p.Properties, ct.Properties)
 				case "required":
-					p.Required = v.(bool)
+					if required, ok := v.(bool); ok {
+						p.Required = required
+					}
 				case "description":
-					p.Description = v.(string)
+					p.Description, _ = v.(string)
 				case "example":
-					p.Example = v.(string)
+					if example, ok := v.(string); ok {
+						p.Example = example
+					} else {
+						p.Example = stringify(normalizeValue(v))
+					}
 				case "properties":
 					if properties, ok := v.(map[interface{}]interface{}); ok {
 						props := make(map[string]interface{})
 						for name, prop := range properties {
-							props[name.(string)] = prop
+							props[stringify(name)] = prop
 						}
-						p.Properties = tra.handleCustomTypeProperties(props)
+						p.Properties = mergeProperties(p.Properties, tra.customTypeProperties(props, stack))
 					}
 				}
 			}
+			handlePropertyFacets(&p, normalizeValue(val).(map[string]interface{}))
 			return p
 		}
 
@@ -555,6 +945,33 @@ func (tra *RamlTransformer) handleCustomTypeProperties(properties map[string]int
 	return
 }
 
+// handlePropertyFacets It sets the facets declared by the property, e.g. enum, minimum or pattern
+func handlePropertyFacets(prop *definition.CustomTypeProperty, facets map[string]interface{}) {
+	if enum, ok := facets["enum"].([]interface{}); ok {
+		prop.Enum = enum
+	}
+
+	prop.Default = facets["default"]
+	prop.Format, _ = facets["format"].(string)
+	prop.Pattern = stringPtr(facet(facets, "pattern"))
+	prop.MinLength = intPtr(facet(facets, "minLength"))
+	prop.MaxLength = intPtr(facet(facets, "maxLength"))
+	prop.Min = floatPtr(facet(facets, "minimum"))
+	prop.Max = floatPtr(facet(facets, "maximum"))
+	prop.MultipleOf = floatPtr(facet(facets, "multipleOf"))
+	prop.MinItems = intPtr(facet(facets, "minItems"))
+	prop.MaxItems = intPtr(facet(facets, "maxItems"))
+	prop.UniqueItems, _ = facets["uniqueItems"].(bool)
+	prop.MinProperties = intPtr(facet(facets, "minProperties"))
+	prop.MaxProperties = intPtr(facet(facets, "maxProperties"))
+	prop.Discriminator, _ = facets["discriminator"].(string)
+	prop.DiscriminatorValue, _ = facets["discriminatorValue"].(string)
+
+	if additional, ok := facets["additionalProperties"].(bool); ok {
+		prop.AdditionalProperties = &additional
+	}
+}
+
 // buildTransactions It holds the responsibility to create multiple transactions based on RAML's request/responses
 func (tra *RamlTransformer) buildTransactions(headers map[raml.HTTPHeader]raml.Header, bodies *raml.Bodies, responses map[raml.HTTPCode]raml.Response) (transactions []definition.Transaction) {
 	req := tra.handleRequest(headers, bodies)
@@ -592,6 +1009,47 @@ func (tra *RamlTransformer) buildTransactions(headers map[raml.HTTPHeader]raml.H
 	return
 }
 
+// formatExample Returns the example's value pretty-printed, JSON is indented for the JSON's media types and structured values
+func formatExample(value interface{}, mediaType string) string {
+	s, isString := value.(string)
+	if !isString {
+		return stringify(value)
+	}
+
+	var out bytes.Buffer
+	if strings.Contains(mediaType, "json") && json.Indent(&out, []byte(s), "", "    ") == nil {
+		return out.String()
+	}
+
+	return s
+}
+
+// normalizeValue Converts the YAML's maps of the value into the structure understood by encoding/json (map[string]interface{})
+func normalizeValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			m[fmt.Sprint(k)] = normalizeValue(item)
+		}
+		return m
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			m[k] = normalizeValue(item)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(val))
+		for i, item := range val {
+			s[i] = normalizeValue(item)
+		}
+		return s
+	}
+
+	return v
+}
+
 // removeLibraryName It removes the library's namespace from a string
 func (tra *RamlTransformer) removeLibraryName(name string) string {
 	s := strings.Split(strings.TrimSpace(name), ".")
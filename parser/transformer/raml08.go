@@ -0,0 +1,473 @@
+package transformer
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Jumpscale/go-raml/raml"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/parser/walker"
+)
+
+// raml08Methods HTTP methods declared on RAML 0.8's resources
+var raml08Methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace", "connect"}
+
+// raml08 Upgrades RAML 0.8 documents to go-raml's definition, so they are transformed as the RAML 1.0's documents
+type raml08 struct {
+	doc map[string]interface{}
+	// schemas are the global schemas by name, the bodies' schemas referencing them are transformed into their types
+	schemas map[string]string
+}
+
+// upgradeRaml08 Returns the go-raml's definition equivalent to the RAML 0.8's document given
+func upgradeRaml08(doc map[string]interface{}) raml.APIDefinition {
+	r := &raml08{doc: doc, schemas: make(map[string]string)}
+
+	for name, schema := range declarations(doc["schemas"]) {
+		r.schemas[name] = stringify(schema)
+	}
+
+	ramlDef := raml.APIDefinition{
+		Title:             stringify(doc["title"]),
+		Version:           stringify(doc["version"]),
+		BaseURI:           stringify(doc["baseUri"]),
+		BaseURIParameters: r.namedParameters(doc["baseUriParameters"], true),
+		Protocols:         stringSlice(doc["protocols"]),
+		MediaType:         stringify(doc["mediaType"]),
+		Documentation:     r.documentation(),
+		Types:             r.types(),
+		Traits:            r.traits(),
+		ResourceTypes:     r.resourceTypes(),
+		SecuritySchemes:   r.securitySchemes(),
+		SecuredBy:         r.options(doc["securedBy"]),
+		Resources:         make(map[string]raml.Resource),
+	}
+
+	for uri, v := range doc {
+		if strings.HasPrefix(uri, "/") {
+			ramlDef.Resources[uri] = r.resource(uri, v)
+		}
+	}
+
+	return ramlDef
+}
+
+// documentation Transforms the documentation's list of title and content
+func (r *raml08) documentation() (docs []raml.Documentation) {
+	list, _ := r.doc["documentation"].([]interface{})
+	for _, item := range list {
+		doc := mapOf(item)
+		docs = append(docs, raml.Documentation{Title: stringify(doc["title"]), Content: stringify(doc["content"])})
+	}
+
+	return
+}
+
+// types Transforms the schemas into types, JSON schemas' properties are kept as the type's properties
+func (r *raml08) types() map[string]raml.Type {
+	types := make(map[string]raml.Type)
+
+	for name, schema := range r.schemas {
+		types[name] = schemaType(schema)
+	}
+
+	return types
+}
+
+// traits Upgrades the traits, their parameters are kept as declared
+func (r *raml08) traits() map[string]raml.Trait {
+	traits := make(map[string]raml.Trait)
+
+	for name, v := range declarations(r.doc["traits"]) {
+		m := mapOf(v)
+
+		traits[name] = raml.Trait{
+			Name:            name,
+			Usage:           stringify(m["usage"]),
+			Description:     stringify(m["description"]),
+			QueryParameters: r.namedParameters(m["queryParameters"], false),
+			Headers:         r.headers(m["headers"]),
+			Bodies:          r.bodies(m["body"]),
+			Responses:       r.responses(m["responses"]),
+		}
+	}
+
+	return traits
+}
+
+// resourceTypes Upgrades the resource types, their methods are set to the fields named by the HTTP method (e.g. Get)
+func (r *raml08) resourceTypes() map[string]raml.ResourceType {
+	resourceTypes := make(map[string]raml.ResourceType)
+
+	for name, v := range declarations(r.doc["resourceTypes"]) {
+		m := mapOf(v)
+
+		rt := raml.ResourceType{
+			Name:          name,
+			Usage:         stringify(m["usage"]),
+			Description:   stringify(m["description"]),
+			URIParameters: r.namedParameters(m["uriParameters"], true),
+		}
+
+		rtVal := reflect.ValueOf(&rt).Elem()
+		for _, methodName := range raml08Methods {
+			// The optional methods (e.g. get?) are named with their question mark, they only apply to the resources declaring them
+			key := methodName
+			method, ok := m[key]
+			if !ok {
+				key = methodName + "?"
+				if method, ok = m[key]; !ok {
+					continue
+				}
+			}
+
+			field := rtVal.FieldByName(strings.Title(methodName))
+			if !field.IsValid() || field.Kind() != reflect.Ptr {
+				continue
+			}
+
+			rtm := reflect.New(field.Type().Elem())
+			merge(rtm.Elem(), reflect.ValueOf(*r.method(methodName, method)))
+			if name := rtm.Elem().FieldByName("Name"); name.IsValid() && name.Kind() == reflect.String {
+				name.SetString(key)
+			}
+			field.Set(rtm)
+		}
+
+		resourceTypes[name] = rt
+	}
+
+	return resourceTypes
+}
+
+// securitySchemes Upgrades the security schemes
+func (r *raml08) securitySchemes() map[string]raml.SecurityScheme {
+	schemes := make(map[string]raml.SecurityScheme)
+
+	for name, v := range declarations(r.doc["securitySchemes"]) {
+		m := mapOf(v)
+
+		scheme := raml.SecurityScheme{
+			Type:        stringify(m["type"]),
+			Description: stringify(m["description"]),
+			Settings:    mapOf(m["settings"]),
+		}
+
+		describedBy := mapOf(m["describedBy"])
+		scheme.DescribedBy.Headers = r.headers(describedBy["headers"])
+		scheme.DescribedBy.Responses = r.responses(describedBy["responses"])
+
+		schemes[name] = scheme
+	}
+
+	return schemes
+}
+
+// resource Upgrades the resource, its methods and its nested resources
+func (r *raml08) resource(uri string, v interface{}) raml.Resource {
+	m := mapOf(v)
+
+	res := raml.Resource{
+		URI:           uri,
+		DisplayName:   stringify(m["displayName"]),
+		Description:   stringify(m["description"]),
+		URIParameters: r.namedParameters(m["uriParameters"], true),
+		Is:            r.options(m["is"]),
+		SecuredBy:     r.options(m["securedBy"]),
+	}
+
+	if opts := r.options([]interface{}{m["type"]}); len(opts) > 0 {
+		res.Type = &opts[0]
+	}
+
+	for _, name := range raml08Methods {
+		if method, ok := m[name]; ok {
+			res.Methods = append(res.Methods, r.method(name, method))
+		}
+	}
+
+	for key, nested := range m {
+		if strings.HasPrefix(key, "/") {
+			if res.Nested == nil {
+				res.Nested = make(map[string]*raml.Resource)
+			}
+
+			child := r.resource(key, nested)
+			res.Nested[key] = &child
+		}
+	}
+
+	return res
+}
+
+// method Upgrades the resource's method
+func (r *raml08) method(name string, v interface{}) *raml.Method {
+	m := mapOf(v)
+
+	return &raml.Method{
+		Name:            strings.ToUpper(name),
+		Description:     stringify(m["description"]),
+		QueryParameters: r.namedParameters(m["queryParameters"], false),
+		Headers:         r.headers(m["headers"]),
+		Bodies:          r.bodies(m["body"]),
+		Responses:       r.responses(m["responses"]),
+		Is:              r.options(m["is"]),
+		SecuredBy:       r.options(m["securedBy"]),
+	}
+}
+
+// responses Upgrades the responses by their status code
+func (r *raml08) responses(v interface{}) (responses map[raml.HTTPCode]raml.Response) {
+	for code, resp := range mapOf(v) {
+		if responses == nil {
+			responses = make(map[raml.HTTPCode]raml.Response)
+		}
+
+		m := mapOf(resp)
+
+		responses[raml.HTTPCode(code)] = raml.Response{
+			Description: stringify(m["description"]),
+			Headers:     r.headers(m["headers"]),
+			Bodies:      r.bodies(m["body"]),
+		}
+	}
+
+	return
+}
+
+// bodies Upgrades the bodies by their media type, the bodies without media type use the document's media type.
+// JSON bodies are upgraded as application/json's bodies as well, which describe their schema's type
+func (r *raml08) bodies(v interface{}) (bodies raml.Bodies) {
+	m := mapOf(v)
+	if len(m) == 0 {
+		return
+	}
+
+	byMediaType := make(map[string]interface{})
+	for key, body := range m {
+		if strings.Contains(key, "/") {
+			byMediaType[key] = body
+		}
+	}
+
+	if len(byMediaType) == 0 {
+		byMediaType[stringify(r.doc["mediaType"])] = m
+	}
+
+	var mediaTypes []string
+	for mediaType := range byMediaType {
+		mediaTypes = append(mediaTypes, mediaType)
+	}
+
+	sort.Strings(mediaTypes)
+
+	for _, mediaType := range mediaTypes {
+		body := mapOf(byMediaType[mediaType])
+		schema := stringify(body["schema"])
+
+		if bodies.ForMIMEType == nil {
+			bodies.ForMIMEType = make(map[string]raml.Body)
+		}
+
+		// The schema is either the name of a global schema or declared inline
+		bodies.ForMIMEType[mediaType] = raml.Body{
+			Description:    stringify(body["description"]),
+			Type:           schema,
+			Example:        stringify(body["example"]),
+			FormParameters: r.namedParameters(body["formParameters"], false),
+		}
+
+		// The JSON body's type is its schema's, its description and example are kept by its media type's body
+		if mediaType == "application/json" {
+			bodies.ApplicationJSON = r.jsonBody(schema)
+		}
+	}
+
+	return
+}
+
+// jsonBody Upgrades the JSON body's schema, the schemas declared inline are upgraded with their properties
+func (r *raml08) jsonBody(schema string) *raml.BodiesProperty {
+	if _, ok := r.schemas[schema]; ok || schema == "" {
+		return &raml.BodiesProperty{Type: schema}
+	}
+
+	t := schemaType(schema)
+
+	return &raml.BodiesProperty{Type: t.Type, Properties: t.Properties}
+}
+
+// namedParameters Upgrades the named parameters, the URI's parameters are required unless declared otherwise
+func (r *raml08) namedParameters(v interface{}, required bool) (params map[string]raml.NamedParameter) {
+	for name, param := range mapOf(v) {
+		if params == nil {
+			params = make(map[string]raml.NamedParameter)
+		}
+
+		m := mapOf(param)
+
+		p := raml.NamedParameter{
+			Description: stringify(m["description"]),
+			Type:        stringify(m["type"]),
+			Required:    required,
+			Pattern:     stringPtr(facet(m, "pattern")),
+			MinLength:   intPtr(facet(m, "minLength")),
+			MaxLength:   intPtr(facet(m, "maxLength")),
+			Minimum:     floatPtr(facet(m, "minimum")),
+			Maximum:     floatPtr(facet(m, "maximum")),
+			Example:     m["example"],
+		}
+
+		if p.Type == "" {
+			p.Type = "string"
+		}
+
+		if req, ok := m["required"].(bool); ok {
+			p.Required = req
+		}
+
+		params[name] = p
+	}
+
+	return
+}
+
+// headers Upgrades the headers, which are named parameters as well
+func (r *raml08) headers(v interface{}) (headers map[raml.HTTPHeader]raml.Header) {
+	for name, param := range r.namedParameters(v, false) {
+		if headers == nil {
+			headers = make(map[raml.HTTPHeader]raml.Header)
+		}
+
+		headers[raml.HTTPHeader(name)] = raml.Header(param)
+	}
+
+	return
+}
+
+// options Upgrades the traits and security schemes applied, the anonymous security scheme (null) is discarded
+func (r *raml08) options(v interface{}) (opts []raml.DefinitionChoice) {
+	list, _ := v.([]interface{})
+
+	for _, item := range list {
+		switch opt := item.(type) {
+		case string:
+			opts = append(opts, raml.DefinitionChoice{Name: opt})
+		case map[string]interface{}:
+			for name, params := range opt {
+				opts = append(opts, raml.DefinitionChoice{Name: name, Parameters: mapOf(params)})
+			}
+		}
+	}
+
+	return
+}
+
+// schemaType Returns the type of the schema, JSON schemas are described by their type and properties
+func schemaType(schema string) (t raml.Type) {
+	var s map[string]interface{}
+
+	if err := json.Unmarshal([]byte(schema), &s); err != nil {
+		t.Type = schema
+		return
+	}
+
+	t.Type = jsonSchemaType(s)
+	t.Description = stringify(s["description"])
+	t.Properties = jsonSchemaProperties(s)
+
+	return
+}
+
+// jsonSchemaProperties Returns the JSON schema's properties as the properties of the RAML 1.0's types
+func jsonSchemaProperties(s map[string]interface{}) map[string]interface{} {
+	properties := mapOf(s["properties"])
+	if len(properties) == 0 {
+		return nil
+	}
+
+	// Draft 4 declares the required properties in the object, draft 3 in each property
+	required := stringSlice(s["required"])
+
+	props := make(map[string]interface{}, len(properties))
+	for name, v := range properties {
+		p := mapOf(v)
+
+		prop := map[interface{}]interface{}{
+			"type":     jsonSchemaType(p),
+			"required": p["required"] == true,
+		}
+
+		for _, r := range required {
+			if r == name {
+				prop["required"] = true
+			}
+		}
+
+		if description := stringify(p["description"]); description != "" {
+			prop["description"] = description
+		}
+
+		if nested := jsonSchemaProperties(p); nested != nil {
+			inner := make(map[interface{}]interface{}, len(nested))
+			for k, n := range nested {
+				inner[k] = n
+			}
+			prop["properties"] = inner
+		}
+
+		props[name] = prop
+	}
+
+	return props
+}
+
+// jsonSchemaType Returns the JSON schema's type, references are described by the name of the schema referenced
+func jsonSchemaType(s map[string]interface{}) string {
+	if ref := stringify(s["$ref"]); ref != "" {
+		return refName(ref)
+	}
+
+	switch t := s["type"].(type) {
+	case string:
+		if t == "array" {
+			if items := mapOf(s["items"]); len(items) > 0 {
+				return jsonSchemaType(items) + "[]"
+			}
+		}
+		return t
+	case []interface{}:
+		// The first type is the main one, e.g. ["string", "null"]
+		if len(t) > 0 {
+			return stringify(t[0])
+		}
+	}
+
+	return "object"
+}
+
+// declarations Returns the declarations by name, RAML 0.8 declares them as a list of maps
+func declarations(v interface{}) map[string]interface{} {
+	if list, ok := v.([]interface{}); ok {
+		m := make(map[string]interface{})
+		for _, item := range list {
+			for name, decl := range mapOf(item) {
+				m[name] = decl
+			}
+		}
+		return m
+	}
+
+	return mapOf(v)
+}
+
+func mapOf(v interface{}) map[string]interface{} {
+	m, _ := v.(map[string]interface{})
+	return m
+}
+
+func facet(m map[string]interface{}, key string) *walker.ObjectWalker {
+	el := walker.NewObjectWalker(m[key])
+	return &el
+}
@@ -0,0 +1,418 @@
+package transformer
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/Jumpscale/go-raml/raml"
+)
+
+// ramlParamRe Matches the parameters of resource types and traits, e.g. <<resourcePathName | !singularize>>
+var ramlParamRe = regexp.MustCompile(`<<\s*([\w-]+)\s*((?:\|\s*![\w-]+\s*)*)>>`)
+
+// ramlUnmergedFields Fields describing the resource types and traits themselves, which aren't applied
+var ramlUnmergedFields = map[string]bool{"Name": true, "DisplayName": true, "Usage": true, "Type": true}
+
+// applyResourceType Returns the resource with the resource type it declares applied, the resource's own declarations prevail
+func (tra *RamlTransformer) applyResourceType(ramlRes raml.Resource, parent string) raml.Resource {
+	if ramlRes.Type == nil {
+		return ramlRes
+	}
+
+	rt, ok := tra.resourceType(ramlRes.Type.Name)
+	if !ok {
+		return ramlRes
+	}
+
+	params := tra.resourceParams(ramlRes.Type.Parameters, parent+ramlRes.URI)
+
+	// The methods are shared with the raml's definition, they're copied so the resource type only applies to this resource
+	methods := make([]*raml.Method, len(ramlRes.Methods))
+	for i, m := range ramlRes.Methods {
+		method := *m
+		methods[i] = &method
+	}
+	ramlRes.Methods = methods
+
+	rtVal := substitute(reflect.ValueOf(rt), params)
+	resVal := reflect.ValueOf(&ramlRes).Elem()
+
+	merge(resVal, rtVal)
+
+	// The resource type's methods are declared as fields named by the HTTP method (e.g. Get, Post)
+	for i := 0; i < rtVal.NumField(); i++ {
+		field := rtVal.Type().Field(i)
+		name := strings.ToUpper(field.Name)
+
+		if !isHTTPMethod(name) || field.Type.Kind() != reflect.Ptr || rtVal.Field(i).IsNil() {
+			continue
+		}
+
+		method := methodByName(ramlRes.Methods, name)
+		if method == nil {
+			// RAML 0.8's optional methods (e.g. get?) are only applied to the methods the resource declares
+			if optional(rtVal.Field(i).Elem()) {
+				continue
+			}
+
+			method = &raml.Method{Name: name}
+			ramlRes.Methods = append(ramlRes.Methods, method)
+		}
+
+		methodParams := copyParams(params)
+		methodParams["methodName"] = strings.ToLower(name)
+
+		merge(reflect.ValueOf(method).Elem(), substitute(rtVal.Field(i).Elem(), methodParams))
+	}
+
+	return ramlRes
+}
+
+// applyTraits Returns the method with the traits applied by the resource and the method itself, the method's own declarations prevail
+func (tra *RamlTransformer) applyTraits(ramlRes raml.Resource, ramlMethod *raml.Method, parent string) *raml.Method {
+	method := *ramlMethod
+
+	for _, opt := range append(append([]raml.DefinitionChoice{}, ramlMethod.Is...), ramlRes.Is...) {
+		trait, ok := tra.trait(opt.Name)
+		if !ok {
+			continue
+		}
+
+		params := tra.resourceParams(opt.Parameters, parent+ramlRes.URI)
+		params["methodName"] = strings.ToLower(method.Name)
+
+		merge(reflect.ValueOf(&method).Elem(), substitute(reflect.ValueOf(trait), params))
+	}
+
+	return &method
+}
+
+// resourceParams Returns the parameters given with the reserved parameters of the resource's path
+func (tra *RamlTransformer) resourceParams(given map[string]interface{}, path string) map[string]string {
+	params := map[string]string{
+		"resourcePath":     path,
+		"resourcePathName": resourcePathName(path),
+	}
+
+	for k, v := range given {
+		params[k] = fmt.Sprint(v)
+	}
+
+	return params
+}
+
+// resourceType Returns the resource type declared with the name given, the libraries' ones are prefixed by the library's name
+func (tra *RamlTransformer) resourceType(name string) (rt raml.ResourceType, ok bool) {
+	if rt, ok = tra.ramlDef.ResourceTypes[name]; ok {
+		return
+	}
+
+	if lib, local := tra.library(name); lib != nil {
+		rt, ok = lib.ResourceTypes[local]
+	}
+
+	return
+}
+
+// trait Returns the trait declared with the name given, the libraries' ones are prefixed by the library's name
+func (tra *RamlTransformer) trait(name string) (trait raml.Trait, ok bool) {
+	if trait, ok = tra.ramlDef.Traits[name]; ok {
+		return
+	}
+
+	if lib, local := tra.library(name); lib != nil {
+		trait, ok = lib.Traits[local]
+	}
+
+	return
+}
+
+// library Returns the library where the name given is declared and the name within it, e.g. lib.nested.name
+func (tra *RamlTransformer) library(name string) (lib *raml.Library, local string) {
+	s := strings.Split(name, ".")
+	libs := tra.ramlDef.Libraries
+
+	for _, libName := range s[:len(s)-1] {
+		if lib = libs[libName]; lib == nil {
+			return
+		}
+		libs = lib.Libraries
+	}
+
+	local = s[len(s)-1]
+
+	return
+}
+
+// resourcePathName Returns the rightmost segment of the path which isn't an URI parameter
+func resourcePathName(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	for i := len(segments) - 1; i >= 0; i-- {
+		if !strings.HasPrefix(segments[i], "{") {
+			return segments[i]
+		}
+	}
+
+	return ""
+}
+
+// substitute Returns a deep copy of the value given with the parameters replaced in all its strings
+func substitute(v reflect.Value, params map[string]string) reflect.Value {
+	switch v.Kind() {
+	case reflect.String:
+		s := reflect.New(v.Type()).Elem()
+		s.SetString(substituteParams(v.String(), params))
+		return s
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		p := reflect.New(v.Type().Elem())
+		p.Elem().Set(substitute(v.Elem(), params))
+		return p
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		i := reflect.New(v.Type()).Elem()
+		i.Set(substitute(v.Elem(), params))
+		return i
+	case reflect.Struct:
+		s := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.NumField(); i++ {
+			// Unexported fields can't be set, they are left out of the copy
+			if s.Field(i).CanSet() {
+				s.Field(i).Set(substitute(v.Field(i), params))
+			}
+		}
+		return s
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		m := reflect.MakeMap(v.Type())
+		for _, k := range v.MapKeys() {
+			m.SetMapIndex(substitute(k, params), substitute(v.MapIndex(k), params))
+		}
+		return m
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		s := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			s.Index(i).Set(substitute(v.Index(i), params))
+		}
+		return s
+	}
+
+	return v
+}
+
+// merge Applies the src's fields to the dst's fields with the same name, the dst's values prevail and the maps are joined.
+// The fields describing the resource type or trait itself are only skipped at its top level, e.g. the bodies' types are applied
+func merge(dst, src reflect.Value) {
+	mergeFields(dst, src, ramlUnmergedFields)
+}
+
+// mergeFields Merges the fields which aren't skipped, the nested structs and the maps' structs (e.g. the responses by code) are merged deeply
+func mergeFields(dst, src reflect.Value, skipped map[string]bool) {
+	for i := 0; i < src.NumField(); i++ {
+		name := src.Type().Field(i).Name
+		if skipped[name] {
+			continue
+		}
+
+		d := dst.FieldByName(name)
+		s := src.Field(i)
+
+		if !d.IsValid() || !d.CanSet() || d.Type() != s.Type() {
+			continue
+		}
+
+		switch d.Kind() {
+		case reflect.Map:
+			if s.Len() == 0 {
+				continue
+			}
+			// The map is copied, the destination's one can be shared with the raml's definition
+			m := reflect.MakeMap(d.Type())
+			for _, k := range d.MapKeys() {
+				m.SetMapIndex(k, d.MapIndex(k))
+			}
+			for _, k := range s.MapKeys() {
+				existing := m.MapIndex(k)
+				switch {
+				case !existing.IsValid():
+					m.SetMapIndex(k, s.MapIndex(k))
+				case existing.Kind() == reflect.Struct:
+					v := reflect.New(existing.Type()).Elem()
+					v.Set(existing)
+					mergeFields(v, s.MapIndex(k), nil)
+					m.SetMapIndex(k, v)
+				}
+			}
+			d.Set(m)
+		case reflect.Struct:
+			mergeFields(d, s, nil)
+		default:
+			if isZero(d) {
+				d.Set(s)
+			}
+		}
+	}
+}
+
+func isZero(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+		return v.IsNil() || (v.Kind() == reflect.Slice && v.Len() == 0)
+	}
+
+	return reflect.DeepEqual(v.Interface(), reflect.Zero(v.Type()).Interface())
+}
+
+// substituteParams Replaces the parameters in the string given applying their transformations, unknown parameters are kept
+func substituteParams(s string, params map[string]string) string {
+	return ramlParamRe.ReplaceAllStringFunc(s, func(match string) string {
+		m := ramlParamRe.FindStringSubmatch(match)
+
+		value, ok := params[m[1]]
+		if !ok {
+			return match
+		}
+
+		for _, fn := range strings.Split(m[2], "|") {
+			value = transformParam(strings.TrimPrefix(strings.TrimSpace(fn), "!"), value)
+		}
+
+		return value
+	})
+}
+
+// transformParam Applies the RAML's transformation function given to the parameter's value
+func transformParam(fn, value string) string {
+	switch fn {
+	case "singularize":
+		return singularize(value)
+	case "pluralize":
+		return pluralize(value)
+	case "uppercase":
+		return strings.ToUpper(value)
+	case "lowercase":
+		return strings.ToLower(value)
+	case "lowercamelcase":
+		return camelCase(value, false)
+	case "uppercamelcase":
+		return camelCase(value, true)
+	case "lowerunderscorecase":
+		return strings.ToLower(strings.Join(words(value), "_"))
+	case "upperunderscorecase":
+		return strings.ToUpper(strings.Join(words(value), "_"))
+	case "lowerhyphencase":
+		return strings.ToLower(strings.Join(words(value), "-"))
+	case "upperhyphencase":
+		return strings.ToUpper(strings.Join(words(value), "-"))
+	}
+
+	return value
+}
+
+func singularize(s string) string {
+	switch {
+	case strings.HasSuffix(s, "ies"):
+		return s[:len(s)-3] + "y"
+	case strings.HasSuffix(s, "sses"), strings.HasSuffix(s, "xes"), strings.HasSuffix(s, "ches"), strings.HasSuffix(s, "shes"):
+		return s[:len(s)-2]
+	case strings.HasSuffix(s, "s") && !strings.HasSuffix(s, "ss"):
+		return s[:len(s)-1]
+	}
+
+	return s
+}
+
+func pluralize(s string) string {
+	switch {
+	case strings.HasSuffix(s, "y") && len(s) > 1 && !strings.ContainsRune("aeiou", rune(s[len(s)-2])):
+		return s[:len(s)-1] + "ies"
+	case strings.HasSuffix(s, "s"), strings.HasSuffix(s, "x"), strings.HasSuffix(s, "ch"), strings.HasSuffix(s, "sh"):
+		return s + "es"
+	}
+
+	return s + "s"
+}
+
+func camelCase(s string, upper bool) string {
+	var out string
+	for i, w := range words(s) {
+		w = strings.ToLower(w)
+		if i > 0 || upper {
+			w = strings.ToUpper(w[:1]) + w[1:]
+		}
+		out += w
+	}
+
+	return out
+}
+
+// words Splits the value by its separators and case changes, e.g. userId, user_id and user-id are user and id
+func words(s string) (ws []string) {
+	var current []rune
+
+	for i, r := range s {
+		switch {
+		case r == '_' || r == '-' || r == ' ':
+			if len(current) > 0 {
+				ws = append(ws, string(current))
+			}
+			current = nil
+			continue
+		case unicode.IsUpper(r) && i > 0 && len(current) > 0 && !unicode.IsUpper(current[len(current)-1]):
+			ws = append(ws, string(current))
+			current = nil
+		}
+		current = append(current, r)
+	}
+
+	if len(current) > 0 {
+		ws = append(ws, string(current))
+	}
+
+	return
+}
+
+func isHTTPMethod(name string) bool {
+	for _, m := range raml08Methods {
+		if strings.ToUpper(m) == name {
+			return true
+		}
+	}
+	return false
+}
+
+func methodByName(methods []*raml.Method, name string) *raml.Method {
+	for _, m := range methods {
+		if strings.ToUpper(m.Name) == name {
+			return m
+		}
+	}
+	return nil
+}
+
+// optional Checks if the resource type's method is optional, its name ends with a question mark, e.g. get?
+func optional(method reflect.Value) bool {
+	name := method.FieldByName("Name")
+	return name.IsValid() && name.Kind() == reflect.String && strings.HasSuffix(name.String(), "?")
+}
+
+func copyParams(params map[string]string) map[string]string {
+	c := make(map[string]string, len(params))
+	for k, v := range params {
+		c[k] = v
+	}
+	return c
+}
@@ -1,6 +1,7 @@
 package transformer
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/Jumpscale/go-raml/raml"
@@ -57,6 +58,29 @@ func TestRamlTransformer_Transform_BaseURI(t *testing.T) {
 	}
 }
 
+func TestRamlTransformer_Transform_Documentation(t *testing.T) {
+	t.Parallel()
+
+	spec := raml.APIDefinition{
+		Documentation: []raml.Documentation{
+			{Title: "Introduction", Content: "Welcome to the API"},
+			{Title: "Authentication", Content: "Use a bearer token"},
+		},
+	}
+	expected := &definition.Api{
+		Documentation: []definition.Section{
+			{Title: "Introduction", Content: "Welcome to the API"},
+			{Title: "Authentication", Content: "Use a bearer token"},
+		},
+	}
+
+	def, err := NewRamlTransformer().Transform(spec)
+
+	if assert.Nil(t, err) {
+		assert.Exactly(t, expected.Documentation, def.Documentation)
+	}
+}
+
 func TestRamlTransformer_Transform_CustomTypes(t *testing.T) {
 	t.Parallel()
 
@@ -86,8 +110,13 @@ func TestRamlTransformer_Transform_CustomTypes(t *testing.T) {
 						Name:        "Simple",
 						Description: "A simple custom type",
 						Type:        "string",
+						Kind:        definition.ScalarKind,
 						Default:     "custom_type",
 						Examples:    []interface{}{"e.g custom_type2", "e.g custom_type1"},
+						NamedExamples: []definition.Example{
+							{Name: "example1", Strict: true, Value: "e.g custom_type2", Content: "e.g custom_type2"},
+							{Strict: true, Value: "e.g custom_type1", Content: "e.g custom_type1"},
+						},
 					},
 				},
 			},
@@ -124,6 +153,7 @@ func TestRamlTransformer_Transform_CustomTypes(t *testing.T) {
 						Name:        "Example",
 						Description: "An example type",
 						Type:        "object",
+						Kind:        definition.ObjectKind,
 						Properties: []definition.CustomTypeProperty{
 							{
 								Name:     "prop1",
@@ -172,6 +202,7 @@ func TestRamlTransformer_Transform_CustomTypes(t *testing.T) {
 					{
 						Name: "Simple",
 						Type: "string",
+						Kind: definition.ScalarKind,
 					},
 				},
 			},
@@ -201,6 +232,7 @@ func TestRamlTransformer_Transform_CustomTypes(t *testing.T) {
 					{
 						Name: "Example 1",
 						Type: "object",
+						Kind: definition.ObjectKind,
 						Properties: []definition.CustomTypeProperty{
 							{
 								Name:     "prop1",
@@ -217,6 +249,7 @@ func TestRamlTransformer_Transform_CustomTypes(t *testing.T) {
 					{
 						Name: "Example 2",
 						Type: "object",
+						Kind: definition.ObjectKind,
 						Properties: []definition.CustomTypeProperty{
 							{
 								Name:     "prop3",
@@ -377,3 +410,679 @@ func TestRamlTransformer_Transform_Resources(t *testing.T) {
 		})
 	}
 }
+
+func TestRamlTransformer_Transform_ResourceTypes(t *testing.T) {
+	t.Parallel()
+
+	spec := raml.APIDefinition{
+		ResourceTypes: map[string]raml.ResourceType{
+			"collection": {
+				Usage:       "Applies to the collections",
+				Description: "The <<resourcePathName>> collection",
+				Get: &raml.ResourceTypeMethod{
+					Description: "Lists the <<resourcePathName>>",
+					Responses: map[raml.HTTPCode]raml.Response{
+						"200": {
+							Bodies: raml.Bodies{
+								ApplicationJSON: &raml.BodiesProperty{Type: "<<item>>[]"},
+							},
+						},
+					},
+				},
+				Post: &raml.ResourceTypeMethod{
+					Description: "Creates a <<resourcePathName | !singularize>> with <<methodName | !uppercase>>",
+				},
+			},
+		},
+		Traits: map[string]raml.Trait{
+			"filterable": {
+				QueryParameters: map[string]raml.NamedParameter{
+					"<<field>>": {Description: "Filters by <<field>>", Type: "string"},
+				},
+			},
+		},
+		Resources: map[string]raml.Resource{
+			"/users": {
+				URI:  "/users",
+				Type: &raml.DefinitionChoice{Name: "collection", Parameters: map[string]interface{}{"item": "User"}},
+				Methods: []*raml.Method{
+					{
+						Name:        "GET",
+						Description: "Lists the active users",
+						Is:          []raml.DefinitionChoice{{Name: "filterable", Parameters: map[string]interface{}{"field": "name"}}},
+						QueryParameters: map[string]raml.NamedParameter{
+							"page": {Type: "integer"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	def, err := NewRamlTransformer().Transform(spec)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	res := def.ResourceGroups[0].Resources[0]
+	assert.Exactly(t, "The users collection", res.Description)
+
+	if !assert.Len(t, res.Actions, 2) {
+		return
+	}
+
+	list := res.Actions[0]
+	assert.Exactly(t, "GET", list.Method)
+	assert.Exactly(t, "Lists the active users", list.Description)
+	assert.Exactly(t, []definition.Parameter{{Name: "name", Description: "Filters by name", Type: "string"}, {Name: "page", Type: "integer"}}, list.Href.Parameters)
+	assert.Exactly(t, []definition.Body{{Type: "User[]", MediaType: "application/json"}}, list.Transactions[0].Response.Body)
+
+	create := res.Actions[1]
+	assert.Exactly(t, "POST", create.Method)
+	assert.Exactly(t, "Creates a user with POST", create.Description)
+
+	// The raml's definition isn't modified by the resource types and traits applied
+	methods := spec.Resources["/users"].Methods
+	if assert.Len(t, methods, 1) {
+		assert.Nil(t, methods[0].Responses)
+		assert.Len(t, methods[0].QueryParameters, 1)
+	}
+}
+
+func TestRamlTransformer_Transform_ResourceTypes_Merge(t *testing.T) {
+	t.Parallel()
+
+	spec := raml.APIDefinition{
+		MediaType: "application/json",
+		ResourceTypes: map[string]raml.ResourceType{
+			"item": {
+				Get: &raml.ResourceTypeMethod{
+					Responses: map[raml.HTTPCode]raml.Response{
+						"200": {
+							Description: "The <<resourcePathName | !singularize>>",
+							Headers:     map[raml.HTTPHeader]raml.Header{"X-Trace": {Type: "string"}},
+							Bodies:      raml.Bodies{Type: "<<item>>"},
+						},
+					},
+				},
+			},
+		},
+		Resources: map[string]raml.Resource{
+			"/users": {
+				URI:  "/users",
+				Type: &raml.DefinitionChoice{Name: "item", Parameters: map[string]interface{}{"item": "User"}},
+				Methods: []*raml.Method{
+					{
+						Name:      "GET",
+						Responses: map[raml.HTTPCode]raml.Response{"200": {Description: "The user found"}},
+					},
+				},
+			},
+		},
+	}
+
+	def, err := NewRamlTransformer().Transform(spec)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	actions := def.ResourceGroups[0].Resources[0].Actions
+	if !assert.Len(t, actions, 1) || !assert.Len(t, actions[0].Transactions, 1) {
+		return
+	}
+
+	// The response only overrides the description, the resource type's headers and body are kept
+	resp := actions[0].Transactions[0].Response
+	assert.Exactly(t, "The user found", resp.Description)
+	if assert.Len(t, resp.Headers, 1) {
+		assert.Exactly(t, "X-Trace", resp.Headers[0].Name)
+	}
+	assert.Exactly(t, []definition.Body{{Type: "User", MediaType: "application/json"}}, resp.Body)
+}
+
+func TestRamlTransformer_Transform_ResourceTypes_Params(t *testing.T) {
+	t.Parallel()
+
+	checks := []struct {
+		Value    string
+		Expected string
+	}{
+		{"<<resourcePathName>>", "users"},
+		{"<<resourcePathName | !singularize>>", "user"},
+		{"<<resourcePathName|!singularize|!uppercamelcase>>", "User"},
+		{"<<resourcePath>>", "/users/{id}"},
+		{"<<item | !pluralize>>", "categories"},
+		{"<<item | !upperunderscorecase>>", "CATEGORY"},
+		{"<<unknown>>", "<<unknown>>"},
+	}
+
+	params := map[string]string{"resourcePath": "/users/{id}", "resourcePathName": resourcePathName("/users/{id}"), "item": "category"}
+
+	for _, check := range checks {
+		assert.Exactly(t, check.Expected, substituteParams(check.Value, params), check.Value)
+	}
+}
+
+func TestRamlTransformer_Transform_Bodies(t *testing.T) {
+	t.Parallel()
+
+	checks := []struct {
+		Name     string
+		Bodies   raml.Bodies
+		Expected []definition.Body
+	}{
+		{
+			"Body without media type",
+			raml.Bodies{Type: "lib.Example", Example: "example"},
+			[]definition.Body{
+				{
+					Type:          "Example",
+					MediaType:     "application/json",
+					Example:       "example",
+					NamedExamples: []definition.Example{{Strict: true, Value: "example", Content: "example"}},
+				},
+			},
+		},
+		{
+			"Bodies for each media type",
+			raml.Bodies{
+				ApplicationJSON: &raml.BodiesProperty{Type: "Example"},
+				ForMIMEType: map[string]raml.Body{
+					"application/xml":  {Type: "Example", Example: "<example/>"},
+					"application/json": {Type: "Example"},
+					"application/vnd.example+json": {
+						Description: "Vendor's example",
+						Properties:  map[string]interface{}{"id": "integer"},
+					},
+					"multipart/form-data": {
+						FormParameters: map[string]raml.NamedParameter{
+							"file": {Type: "file", Required: true},
+						},
+					},
+				},
+			},
+			[]definition.Body{
+				{Type: "Example", MediaType: "application/json"},
+				{
+					Description: "Vendor's example",
+					MediaType:   "application/vnd.example+json",
+					CustomType: &definition.CustomType{
+						Type:       "object",
+						Properties: []definition.CustomTypeProperty{{Name: "id", Type: "integer", Required: true}},
+					},
+				},
+				{
+					Type:          "Example",
+					MediaType:     "application/xml",
+					Example:       "<example/>",
+					NamedExamples: []definition.Example{{Strict: true, Value: "<example/>", Content: "<example/>"}},
+				},
+				{
+					MediaType: "multipart/form-data",
+					CustomType: &definition.CustomType{
+						Type:       "object",
+						Properties: []definition.CustomTypeProperty{{Name: "file", Type: "file", Required: true}},
+					},
+				},
+			},
+		},
+	}
+
+	for _, check := range checks {
+		check := check
+		t.Run(check.Name, func(t *testing.T) {
+			t.Parallel()
+
+			spec := raml.APIDefinition{
+				MediaType: "application/json",
+				Resources: map[string]raml.Resource{
+					"/examples": {
+						URI:     "/examples",
+						Methods: []*raml.Method{{Name: "POST", Bodies: check.Bodies}},
+					},
+				},
+			}
+
+			def, err := NewRamlTransformer().Transform(spec)
+
+			if assert.Nil(t, err) {
+				assert.Exactly(t, check.Expected, def.ResourceGroups[0].Resources[0].Actions[0].Transactions[0].Request.Body)
+			}
+		})
+	}
+}
+
+func TestRamlTransformer_Transform_NamedExamples(t *testing.T) {
+	t.Parallel()
+
+	spec := raml.APIDefinition{
+		MediaType: "application/json",
+		Types: map[string]raml.Type{
+			"User": {
+				Type: "object",
+				Examples: map[string]interface{}{
+					"admin": map[interface{}]interface{}{
+						"displayName": "Administrator",
+						"strict":      false,
+						"value":       map[interface{}]interface{}{"name": "root"},
+					},
+					"guest": map[interface{}]interface{}{"name": "guest"},
+				},
+			},
+		},
+		Resources: map[string]raml.Resource{
+			"/users": {
+				URI: "/users",
+				Methods: []*raml.Method{
+					{
+						Name: "GET",
+						Responses: map[raml.HTTPCode]raml.Response{
+							"200": {
+								Bodies: raml.Bodies{
+									ApplicationJSON: &raml.BodiesProperty{Type: "User"},
+									ForMIMEType: map[string]raml.Body{
+										"application/vnd.user+json": {Type: "User", Example: `{"name":"john"}`},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	def, err := NewRamlTransformer().Transform(spec)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	admin := definition.Example{
+		Name:        "admin",
+		DisplayName: "Administrator",
+		Value:       map[string]interface{}{"name": "root"},
+		Content:     "{\n    \"name\": \"root\"\n}",
+	}
+	guest := definition.Example{
+		Name:    "guest",
+		Strict:  true,
+		Value:   map[string]interface{}{"name": "guest"},
+		Content: "{\n    \"name\": \"guest\"\n}",
+	}
+
+	assert.Exactly(t, []definition.Example{admin, guest}, def.CustomTypes[0].NamedExamples)
+
+	bodies := def.ResourceGroups[0].Resources[0].Actions[0].Transactions[0].Response.Body
+	if !assert.Len(t, bodies, 2) {
+		return
+	}
+
+	assert.Exactly(t, admin.Content, bodies[0].Example)
+	assert.Exactly(t, []definition.Example{admin, guest}, bodies[0].NamedExamples)
+
+	assert.Exactly(t, `{"name":"john"}`, bodies[1].Example)
+	assert.Exactly(t, definition.Example{
+		Strict:  true,
+		Value:   `{"name":"john"}`,
+		Content: "{\n    \"name\": \"john\"\n}",
+	}, bodies[1].NamedExamples[0])
+	assert.Len(t, bodies[1].NamedExamples, 3)
+}
+
+func TestRamlTransformer_Transform_ExamplePayloads(t *testing.T) {
+	t.Parallel()
+
+	spec := raml.APIDefinition{
+		Types: map[string]raml.Type{
+			"Money": {
+				Type: "object",
+				Examples: map[string]interface{}{
+					"annotated": map[interface{}]interface{}{"(internal)": true, "value": 5},
+					"price":     map[interface{}]interface{}{"value": 10, "currency": "EUR"},
+				},
+			},
+		},
+	}
+
+	def, err := NewRamlTransformer().Transform(spec)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	// The payloads holding a value are kept whole, only the examples' declarations are unwrapped
+	assert.Exactly(t, []definition.Example{
+		{Name: "annotated", Strict: true, Value: 5, Content: "5"},
+		{Name: "price", Strict: true, Value: map[string]interface{}{"value": 10, "currency": "EUR"}, Content: "{\n    \"currency\": \"EUR\",\n    \"value\": 10\n}"},
+	}, def.CustomTypes[0].NamedExamples)
+}
+
+func TestRamlTransformer_Transform_Annotations(t *testing.T) {
+	t.Parallel()
+
+	spec := raml.APIDefinition{
+		AnnotationTypes: map[string]raml.AnnotationType{
+			"deprecated": {DisplayName: "Deprecated", Description: "The element will be removed"},
+		},
+		Libraries: map[string]*raml.Library{
+			"common": {
+				AnnotationTypes: map[string]raml.AnnotationType{
+					"owner": {Description: "The team owning the element"},
+				},
+			},
+		},
+		Annotations: map[string]interface{}{"(common.owner)": "platform"},
+		Types: map[string]raml.Type{
+			"User": {Type: "object", Annotations: map[string]interface{}{"(since)": "1.2"}},
+		},
+		Resources: map[string]raml.Resource{
+			"/users": {
+				URI:         "/users",
+				Annotations: map[string]interface{}{"(internal)": nil},
+				URIParameters: map[string]raml.NamedParameter{
+					"id": {Type: "string", Annotations: map[string]interface{}{"(deprecated)": nil}},
+				},
+				Methods: []*raml.Method{
+					{
+						Name: "GET",
+						Annotations: map[string]interface{}{
+							"(since)":      "1.0",
+							"(deprecated)": map[interface{}]interface{}{"replacedBy": "/people"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	def, err := NewRamlTransformer().Transform(spec)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Exactly(t, definition.Annotations{
+		{Name: "owner", Description: "The team owning the element", Value: "platform"},
+	}, def.Annotations)
+	assert.Exactly(t, definition.Annotations{{Name: "since", Value: "1.2"}}, def.CustomTypes[0].Annotations)
+
+	res := def.ResourceGroups[0].Resources[0]
+	assert.True(t, res.Annotations.Has("internal"))
+	assert.True(t, res.Href.Parameters[0].Annotations.Has("deprecated"))
+
+	assert.Exactly(t, definition.Annotations{
+		{
+			Name:        "deprecated",
+			DisplayName: "Deprecated",
+			Description: "The element will be removed",
+			Value:       map[string]interface{}{"replacedBy": "/people"},
+		},
+		{Name: "since", Value: "1.0"},
+	}, res.Actions[0].Annotations)
+}
+
+func TestRamlTransformer_Transform_TypeGraph(t *testing.T) {
+	t.Parallel()
+
+	spec := raml.APIDefinition{
+		Types: map[string]raml.Type{
+			"Base": {
+				Type:       "object",
+				Properties: map[string]interface{}{"id": "integer"},
+			},
+			"Audited": {
+				Properties: map[string]interface{}{"createdAt": "datetime"},
+			},
+			"Cat": {
+				Type:       "Base",
+				Properties: map[string]interface{}{"meows": "boolean"},
+			},
+			"Dog": {
+				Type:       []interface{}{"Base", "Audited"},
+				Properties: map[string]interface{}{"id": "string"},
+			},
+			"Pet":  {Type: "Cat | Dog"},
+			"Pets": {Type: "Pet[]"},
+			"Tags": {
+				Type: "array",
+				Items: map[interface{}]interface{}{
+					"properties": map[interface{}]interface{}{"label": "string"},
+				},
+			},
+			"Node": {
+				Type:       "object",
+				Properties: map[string]interface{}{"children": "Node[]"},
+			},
+		},
+	}
+
+	def, err := NewRamlTransformer().Transform(spec)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	types := make(map[string]definition.CustomType)
+	for _, ct := range def.CustomTypes {
+		types[ct.Name] = ct
+	}
+
+	cat := types["Cat"]
+	assert.Exactly(t, definition.ObjectKind, cat.Kind)
+	assert.Exactly(t, []string{"Base"}, cat.Parents)
+	assert.Exactly(t, []definition.CustomTypeProperty{
+		{Name: "id", Type: "integer", Required: true},
+		{Name: "meows", Type: "boolean", Required: true},
+	}, cat.Properties)
+
+	dog := types["Dog"]
+	assert.Exactly(t, definition.ObjectKind, dog.Kind)
+	assert.Exactly(t, []string{"Base", "Audited"}, dog.Parents)
+	assert.Exactly(t, []definition.CustomTypeProperty{
+		{Name: "createdAt", Type: "datetime", Required: true},
+		{Name: "id", Type: "string", Required: true},
+	}, dog.Properties)
+
+	pet := types["Pet"]
+	assert.Exactly(t, definition.UnionKind, pet.Kind)
+	if assert.Len(t, pet.Members, 2) {
+		assert.Exactly(t, "Cat", pet.Members[0].Name)
+		assert.Exactly(t, []string{"Base"}, pet.Members[0].Parents)
+		assert.Exactly(t, "Dog", pet.Members[1].Name)
+		assert.Len(t, pet.Members[1].Properties, 2)
+	}
+
+	pets := types["Pets"]
+	assert.Exactly(t, definition.ArrayKind, pets.Kind)
+	if assert.NotNil(t, pets.Items) {
+		assert.Exactly(t, "Pet", pets.Items.Name)
+		assert.Exactly(t, definition.UnionKind, pets.Items.Kind)
+	}
+
+	tags := types["Tags"]
+	assert.Exactly(t, definition.ArrayKind, tags.Kind)
+	if assert.NotNil(t, tags.Items) {
+		assert.Exactly(t, definition.ObjectKind, tags.Items.Kind)
+		assert.Exactly(t, []definition.CustomTypeProperty{{Name: "label", Type: "string", Required: true}}, tags.Items.Properties)
+	}
+
+	node := types["Node"]
+	assert.Exactly(t, definition.ObjectKind, node.Kind)
+	assert.Exactly(t, []definition.CustomTypeProperty{{Name: "children", Type: "Node[]", Required: true}}, node.Properties)
+}
+
+func TestRamlTransformer_Transform_PropertyFacets(t *testing.T) {
+	t.Parallel()
+
+	spec := raml.APIDefinition{
+		Types: map[string]raml.Type{
+			"Order": {
+				Type: "object",
+				Properties: map[string]interface{}{
+					"status": map[interface{}]interface{}{
+						"type":    "string",
+						"enum":    []interface{}{"open", "closed"},
+						"default": "open",
+						"pattern": "^[a-z]+$",
+					},
+					"amount": map[interface{}]interface{}{
+						"type":       "number",
+						"format":     "double",
+						"minimum":    0,
+						"maximum":    99.5,
+						"multipleOf": 0.5,
+					},
+					"lines?": map[interface{}]interface{}{
+						"type":        "array",
+						"minItems":    1,
+						"maxItems":    10,
+						"uniqueItems": true,
+					},
+					"details": map[interface{}]interface{}{
+						"type":                 "object",
+						"additionalProperties": false,
+						"discriminator":        "kind",
+						"discriminatorValue":   "order",
+					},
+				},
+			},
+		},
+	}
+
+	def, err := NewRamlTransformer().Transform(spec)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	pattern, min, max, multipleOf := "^[a-z]+$", 0.0, 99.5, 0.5
+	minItems, maxItems, additional := 1, 10, false
+
+	assert.Exactly(t, []definition.CustomTypeProperty{
+		{Name: "amount", Type: "number", Required: true, Format: "double", Min: &min, Max: &max, MultipleOf: &multipleOf},
+		{Name: "details", Type: "object", Required: true, AdditionalProperties: &additional, Discriminator: "kind", DiscriminatorValue: "order"},
+		{Name: "lines", Type: "array", Required: false, MinItems: &minItems, MaxItems: &maxItems, UniqueItems: true},
+		{Name: "status", Type: "string", Required: true, Enum: []interface{}{"open", "closed"}, Default: "open", Pattern: &pattern},
+	}, def.CustomTypes[0].Properties)
+}
+
+func TestRamlTransformer_Transform_PropertyValues(t *testing.T) {
+	t.Parallel()
+
+	spec := raml.APIDefinition{
+		Types: map[string]raml.Type{
+			"Base":    {Type: "object", Properties: map[string]interface{}{"id": "integer"}},
+			"Audited": {Type: "object", Properties: map[string]interface{}{"createdAt": "datetime"}},
+			"Order": {
+				Type: "object",
+				Properties: map[string]interface{}{
+					"quantity": map[interface{}]interface{}{"type": "integer", "example": 42, "required": "yes", "description": 7},
+					"price":    map[interface{}]interface{}{"type": "object", "example": map[interface{}]interface{}{"value": 10}},
+					"owner":    map[interface{}]interface{}{"type": []interface{}{"Base", "Audited"}},
+					"lines":    map[interface{}]interface{}{"type": map[interface{}]interface{}{"type": "array", "items": "string"}},
+				},
+			},
+		},
+	}
+
+	def, err := NewRamlTransformer().Transform(spec)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	order := def.CustomTypeByName("Order")
+
+	// The non-string values don't panic, the examples are stringified and the parents' properties are merged
+	assert.Exactly(t, []definition.CustomTypeProperty{
+		{Name: "lines", Type: "string[]", Required: true},
+		{
+			Name:     "owner",
+			Type:     "object",
+			Required: true,
+			Properties: []definition.CustomTypeProperty{
+				{Name: "createdAt", Type: "datetime", Required: true},
+				{Name: "id", Type: "integer", Required: true},
+			},
+		},
+		{Name: "price", Type: "object", Required: true, Example: "{\n    \"value\": 10\n}"},
+		{Name: "quantity", Type: "integer", Required: true, Example: "42"},
+	}, order.Properties)
+}
+
+func TestRamlTransformer_Transform_InlineTypes_JSON(t *testing.T) {
+	t.Parallel()
+
+	spec := raml.APIDefinition{
+		Types: map[string]raml.Type{
+			"Note": {
+				Type: map[interface{}]interface{}{
+					"type":       "object",
+					"properties": map[interface{}]interface{}{"title": "string"},
+				},
+			},
+		},
+		BaseURIParameters: map[string]raml.NamedParameter{
+			"region": {Type: "string", Enum: []interface{}{"eu", map[interface{}]interface{}{"name": "us"}}},
+		},
+	}
+
+	def, err := NewRamlTransformer().Transform(spec)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	// The YAML's maps are converted, so the definition can be exported as JSON
+	assert.Exactly(t, map[string]interface{}{"type": "object", "properties": map[string]interface{}{"title": "string"}}, def.CustomTypes[0].Type)
+	assert.Exactly(t, []definition.CustomTypeProperty{{Name: "title", Type: "string", Required: true}}, def.CustomTypes[0].Properties)
+	assert.Exactly(t, []interface{}{"eu", map[string]interface{}{"name": "us"}}, def.BaseURIParameters[0].Enum)
+
+	_, err = json.Marshal(def)
+	assert.Nil(t, err)
+}
+
+func TestRamlTransformer_Transform_QueryTypes(t *testing.T) {
+	t.Parallel()
+
+	min, max, pattern := 1.0, 100.0, "^[a-z]+$"
+
+	spec := raml.APIDefinition{
+		Types: map[string]raml.Type{
+			"Limit":    {Type: "integer", Minimum: &min, Description: "Maximum number of items"},
+			"PageSize": {Type: "Limit", Maximum: &max, Default: 20},
+			"Search": {
+				Type: "object",
+				Properties: map[string]interface{}{
+					"q": map[interface{}]interface{}{"type": "string", "pattern": pattern},
+					"sort?": map[interface{}]interface{}{
+						"type": "string",
+						"enum": []interface{}{"asc", "desc"},
+					},
+				},
+			},
+		},
+		Resources: map[string]raml.Resource{
+			"/items": {
+				URI: "/items",
+				Methods: []*raml.Method{
+					{
+						Name: "GET",
+						QueryParameters: map[string]raml.NamedParameter{
+							"size": {Type: "PageSize"},
+						},
+						QueryString: map[string]raml.NamedParameter{
+							// The parameter's name differs from its key
+							"search": {Name: "filters", Type: "Search"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	def, err := NewRamlTransformer().Transform(spec)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Exactly(t, []definition.Parameter{
+		{Name: "q", Type: "string", Required: true, Pattern: &pattern},
+		{Name: "sort", Type: "string", Enum: []interface{}{"asc", "desc"}},
+		{Name: "size", Type: "integer", Description: "Maximum number of items", Min: &min, Max: &max, Default: 20},
+	}, def.ResourceGroups[0].Resources[0].Actions[0].Href.Parameters)
+}
@@ -0,0 +1,202 @@
+package transformer
+
+import (
+	"strings"
+
+	"github.com/Jumpscale/go-raml/raml"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/definition"
+)
+
+// ramlScalarTypes RAML's built-in scalar types
+var ramlScalarTypes = []string{
+	"any", "string", "number", "integer", "boolean", "date-only", "time-only", "datetime-only", "datetime", "file", "nil",
+}
+
+// resolveType Resolves the raml's type expression into the kind of the type, its items, members and parents.
+// The stack holds the types being resolved, so recursive types are kept as references
+func (tra *RamlTransformer) resolveType(ramlType raml.Type, stack []string) (ct definition.CustomType) {
+	switch t := ramlType.Type.(type) {
+	case string:
+		if t == "" {
+			ct = tra.resolveDefault(ramlType)
+			break
+		}
+
+		ct = tra.resolveExpression(t, stack)
+
+		// Single inheritance, e.g. type: Base
+		if ct.Name != "" {
+			ct.Parents = []string{ct.Name}
+			ct.Name, ct.Description = "", ""
+		}
+	case []interface{}:
+		// Multiple inheritance, e.g. type: [Base, Audited]
+		ct.Kind = definition.ObjectKind
+
+		for _, item := range t {
+			name, _ := item.(string)
+			parent := tra.resolveExpression(name, stack)
+
+			ct.Parents = append(ct.Parents, tra.removeLibraryName(name))
+			ct.Properties = mergeProperties(ct.Properties, parent.Properties)
+		}
+	case map[interface{}]interface{}:
+		// Inline type declaration
+		ct = tra.resolveType(inlineType(t), stack)
+	case nil:
+		ct = tra.resolveDefault(ramlType)
+	}
+
+	// The array's items are declared apart when the type is array
+	if ct.Kind == definition.ArrayKind && ct.Items == nil && ramlType.Items != nil {
+		items := tra.resolveItems(ramlType.Items, stack)
+		ct.Items = &items
+	}
+
+	ct.Properties = mergeProperties(ct.Properties, tra.customTypeProperties(ramlType.Properties, stack))
+
+	return
+}
+
+// resolveDefault Resolves the types declared without type, which are objects if they declare properties and strings otherwise
+func (tra *RamlTransformer) resolveDefault(ramlType raml.Type) (ct definition.CustomType) {
+	switch {
+	case ramlType.Properties != nil:
+		ct.Kind = definition.ObjectKind
+	case ramlType.Items != nil:
+		ct.Kind = definition.ArrayKind
+	default:
+		ct.Kind = definition.ScalarKind
+	}
+
+	return
+}
+
+// resolveExpression Resolves a type expression, e.g. Pet[], Cat | Dog, (Cat | Dog)[] or the name of a type
+func (tra *RamlTransformer) resolveExpression(expr string, stack []string) (ct definition.CustomType) {
+	expr = strings.TrimSpace(expr)
+
+	if members := splitUnion(expr); len(members) > 1 {
+		ct.Kind = definition.UnionKind
+		for _, member := range members {
+			ct.Members = append(ct.Members, tra.resolveExpression(member, stack))
+		}
+		return
+	}
+
+	if strings.HasSuffix(expr, "[]") {
+		items := tra.resolveExpression(strings.TrimSuffix(expr, "[]"), stack)
+		ct.Kind = definition.ArrayKind
+		ct.Items = &items
+		return
+	}
+
+	if strings.HasPrefix(expr, "(") && strings.HasSuffix(expr, ")") {
+		return tra.resolveExpression(expr[1:len(expr)-1], stack)
+	}
+
+	ct.Type = tra.removeLibraryName(expr)
+
+	switch {
+	case expr == "object":
+		ct.Kind = definition.ObjectKind
+		return
+	case expr == "array":
+		ct.Kind = definition.ArrayKind
+		return
+	case isScalarType(expr):
+		ct.Kind = definition.ScalarKind
+		return
+	}
+
+	ct.Name = tra.removeLibraryName(expr)
+
+	ramlType, ok := tra.customType(expr)
+	if !ok || inStack(stack, expr) {
+		// Unknown and recursive types are kept as references to the type
+		return
+	}
+
+	resolved := tra.resolveType(ramlType, append(stack, expr))
+	resolved.Name = ct.Name
+	resolved.Type = ct.Type
+	resolved.Description = ramlType.Description
+
+	return resolved
+}
+
+// resolveItems Resolves the array's items, which are either a type expression or an inline type declaration
+func (tra *RamlTransformer) resolveItems(items interface{}, stack []string) definition.CustomType {
+	switch i := items.(type) {
+	case string:
+		return tra.resolveExpression(i, stack)
+	case map[interface{}]interface{}:
+		return tra.resolveType(inlineType(i), stack)
+	}
+
+	return definition.CustomType{}
+}
+
+// propertyType Returns the property's type of the resolved type, e.g. string, Note[] or object
+func propertyType(ct definition.CustomType) string {
+	switch ct.Kind {
+	case definition.ObjectKind:
+		return string(ct.Kind)
+	case definition.ArrayKind:
+		if ct.Items != nil {
+			if items := propertyType(*ct.Items); items != "" && items != string(definition.ObjectKind) {
+				return items + "[]"
+			}
+		}
+		return string(ct.Kind)
+	}
+
+	name, _ := ct.Type.(string)
+	return name
+}
+
+// inlineType Returns the raml's type declared inline, e.g. items: { type: object, properties: {...} }
+func inlineType(m map[interface{}]interface{}) (t raml.Type) {
+	t.Type = m["type"]
+	t.Items = m["items"]
+	t.Description, _ = m["description"].(string)
+
+	if props, ok := m["properties"].(map[interface{}]interface{}); ok {
+		t.Properties = make(map[string]interface{}, len(props))
+		for name, prop := range props {
+			t.Properties[stringify(name)] = prop
+		}
+	}
+
+	return
+}
+
+// splitUnion Splits the union's type expression by its members, the unions grouped in parentheses aren't split
+func splitUnion(expr string) (members []string) {
+	depth, start := 0, 0
+
+	for i, r := range expr {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case '|':
+			if depth == 0 {
+				members = append(members, strings.TrimSpace(expr[start:i]))
+				start = i + 1
+			}
+		}
+	}
+
+	return append(members, strings.TrimSpace(expr[start:]))
+}
+
+func isScalarType(name string) bool {
+	for _, t := range ramlScalarTypes {
+		if t == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,414 @@
+package transformer
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/definition"
+	"github.com/rocket-internet-berlin/RocketLabsRubberDoc/parser/walker"
+)
+
+// defaultSwaggerMediaType Media type used when neither the operation nor the document declare consumes/produces
+const defaultSwaggerMediaType = "application/json"
+
+// schemaFacets Parameter's fields that describe its type, moved into the parameter's schema by the upgrade
+var schemaFacets = []string{
+	"type", "format", "items", "enum", "default", "pattern", "minimum", "maximum",
+	"minLength", "maxLength", "minItems", "maxItems", "uniqueItems", "multipleOf",
+}
+
+// SwaggerTransformer Transforms Swagger 2.0 documents by upgrading them to their OpenAPI 3.x equivalent
+type SwaggerTransformer struct {
+	// doc is the document's root, used to resolve the local references of parameters and responses
+	doc map[string]interface{}
+}
+
+func NewSwaggerTransformer() Transformer {
+	return new(SwaggerTransformer)
+}
+
+func (tra *SwaggerTransformer) Transform(data interface{}) (def *definition.Api, err error) {
+	el, ok := data.(walker.ObjectWalker)
+	if !ok {
+		err = errors.New("The data's struct given isn't supported by the Swagger's Transformer")
+		return
+	}
+
+	if tra.doc, ok = el.Object().(map[string]interface{}); !ok {
+		err = errors.New("The Swagger's document given is empty")
+		return
+	}
+
+	if version := specVersion(tra.doc["swagger"]); version != "2.0" {
+		err = errors.Errorf("The Swagger's version %q given is unsupported", version)
+		return
+	}
+
+	if def, err = NewOpenAPITransformer().Transform(walker.NewObjectWalker(tra.upgrade())); err != nil {
+		return
+	}
+
+	tra.mediaTypes(def)
+
+	return
+}
+
+// mediaTypes Transforms swagger's consumes/produces definition in api's mediaType definition
+func (tra *SwaggerTransformer) mediaTypes(def *definition.Api) {
+	for _, mediaType := range append(stringSlice(tra.doc["consumes"]), stringSlice(tra.doc["produces"])...) {
+		if !hasMediaType(def.MediaTypes, mediaType) {
+			def.MediaTypes = append(def.MediaTypes, definition.MediaType(mediaType))
+		}
+	}
+}
+
+// upgrade Returns the OpenAPI 3.x's document equivalent to the swagger's document
+func (tra *SwaggerTransformer) upgrade() map[string]interface{} {
+	doc := map[string]interface{}{
+		"openapi": "3.0.0",
+		"info":    tra.doc["info"],
+		"servers": tra.servers(),
+		"components": map[string]interface{}{
+			"schemas":         rewriteRefs(tra.doc["definitions"]),
+			"securitySchemes": tra.doc["securityDefinitions"],
+		},
+		"paths": tra.paths(),
+	}
+
+	for _, key := range []string{"tags", "security"} {
+		if v, ok := tra.doc[key]; ok {
+			doc[key] = v
+		}
+	}
+
+	return doc
+}
+
+// servers Builds the servers from the swagger's schemes, host and basePath definition
+func (tra *SwaggerTransformer) servers() (servers []interface{}) {
+	host := stringify(tra.doc["host"])
+	basePath := stringify(tra.doc["basePath"])
+
+	if host == "" {
+		if basePath != "" {
+			servers = append(servers, map[string]interface{}{"url": basePath})
+		}
+		return
+	}
+
+	schemes := stringSlice(tra.doc["schemes"])
+
+	// Without schemes the API is consumed with the same scheme used to access the document, which is unknown
+	if len(schemes) == 0 {
+		return []interface{}{map[string]interface{}{"url": "//" + host + basePath}}
+	}
+
+	for _, scheme := range schemes {
+		servers = append(servers, map[string]interface{}{"url": scheme + "://" + host + basePath})
+	}
+
+	return
+}
+
+// paths Upgrades the swagger's path items and their operations
+func (tra *SwaggerTransformer) paths() map[string]interface{} {
+	paths := make(map[string]interface{})
+
+	items, _ := tra.doc["paths"].(map[string]interface{})
+	for path, v := range items {
+		item, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		// The path item's body is the body of its operations which don't declare their own
+		params, body := tra.parameters(item["parameters"])
+
+		upgraded := make(map[string]interface{})
+		for key, val := range item {
+			switch key {
+			case "parameters":
+				upgraded[key] = params
+			case "get", "put", "post", "delete", "options", "head", "patch":
+				if op, ok := val.(map[string]interface{}); ok {
+					upgraded[key] = tra.operation(op, body)
+				}
+			default:
+				upgraded[key] = val
+			}
+		}
+
+		paths[path] = upgraded
+	}
+
+	return paths
+}
+
+// operation Upgrades a swagger's operation, moving body and form parameters into the request body.
+// The body inherited from the path item is the request body unless the operation declares one
+func (tra *SwaggerTransformer) operation(op map[string]interface{}, inherited map[string]interface{}) map[string]interface{} {
+	upgraded := make(map[string]interface{})
+
+	for _, key := range []string{"summary", "description", "operationId", "tags", "security", "deprecated"} {
+		if v, ok := op[key]; ok {
+			upgraded[key] = v
+		}
+	}
+
+	params, body := tra.parameters(op["parameters"])
+	upgraded["parameters"] = params
+
+	if body == nil {
+		body = inherited
+	}
+
+	consumes := tra.mediaTypesOf(op, "consumes")
+
+	if body != nil {
+		if body["in"] == "formData" {
+			consumes = formMediaTypes(consumes, body)
+		}
+
+		content := make(map[string]interface{})
+		for _, mediaType := range consumes {
+			content[mediaType] = map[string]interface{}{"schema": body["schema"]}
+		}
+
+		upgraded["requestBody"] = map[string]interface{}{
+			"description": body["description"],
+			"required":    body["required"],
+			"content":     content,
+		}
+	}
+
+	if responses, ok := op["responses"].(map[string]interface{}); ok {
+		produces := tra.mediaTypesOf(op, "produces")
+		upgradedResponses := make(map[string]interface{})
+
+		for code, resp := range responses {
+			upgradedResponses[code] = tra.response(resp, produces)
+		}
+
+		upgraded["responses"] = upgradedResponses
+	}
+
+	return upgraded
+}
+
+// parameters Upgrades the swagger's parameters. The body parameter, or the form parameters combined, are returned apart
+func (tra *SwaggerTransformer) parameters(v interface{}) (params []interface{}, body map[string]interface{}) {
+	list, _ := v.([]interface{})
+
+	var form map[string]interface{}
+
+	for _, item := range list {
+		param, ok := tra.resolve(item, "#/parameters/").(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		switch param["in"] {
+		case "body":
+			body = map[string]interface{}{
+				"in":          "body",
+				"description": param["description"],
+				"required":    param["required"],
+				"schema":      rewriteRefs(param["schema"]),
+			}
+		case "formData":
+			if form == nil {
+				form = map[string]interface{}{
+					"in":       "formData",
+					"required": false,
+					"schema": map[string]interface{}{
+						"type":       "object",
+						"properties": map[string]interface{}{},
+					},
+				}
+			}
+
+			schema := form["schema"].(map[string]interface{})
+			schema["properties"].(map[string]interface{})[stringify(param["name"])] = parameterSchema(param)
+
+			if param["required"] == true {
+				form["required"] = true
+				required, _ := schema["required"].([]interface{})
+				schema["required"] = append(required, param["name"])
+			}
+
+			if param["type"] == "file" {
+				form["file"] = true
+			}
+		default:
+			upgraded := map[string]interface{}{"schema": parameterSchema(param)}
+			for _, key := range []string{"name", "in", "description", "required"} {
+				if v, ok := param[key]; ok {
+					upgraded[key] = v
+				}
+			}
+
+			if example, ok := param["x-example"]; ok {
+				upgraded["example"] = example
+			}
+
+			params = append(params, upgraded)
+		}
+	}
+
+	if body == nil {
+		body = form
+	}
+
+	return
+}
+
+// response Upgrades a swagger's response, its schema and examples are declared for each media type produced
+func (tra *SwaggerTransformer) response(v interface{}, produces []string) map[string]interface{} {
+	resp, _ := tra.resolve(v, "#/responses/").(map[string]interface{})
+
+	upgraded := map[string]interface{}{
+		"description": resp["description"],
+	}
+
+	if headers, ok := resp["headers"].(map[string]interface{}); ok {
+		upgradedHeaders := make(map[string]interface{})
+		for name, h := range headers {
+			header, _ := h.(map[string]interface{})
+			upgradedHeaders[name] = map[string]interface{}{
+				"description": header["description"],
+				"schema":      parameterSchema(header),
+			}
+		}
+		upgraded["headers"] = upgradedHeaders
+	}
+
+	examples, _ := resp["examples"].(map[string]interface{})
+
+	if schema, ok := resp["schema"]; ok || len(examples) > 0 {
+		content := make(map[string]interface{})
+
+		for _, mediaType := range produces {
+			media := map[string]interface{}{"schema": rewriteRefs(schema)}
+			if example, ok := examples[mediaType]; ok {
+				media["example"] = example
+			}
+			content[mediaType] = media
+		}
+
+		// Examples of media types not declared by produces are kept as well
+		for mediaType, example := range examples {
+			if _, ok := content[mediaType]; !ok {
+				content[mediaType] = map[string]interface{}{"schema": rewriteRefs(schema), "example": example}
+			}
+		}
+
+		upgraded["content"] = content
+	}
+
+	return upgraded
+}
+
+// mediaTypesOf Returns the operation's media types for the key given (consumes, produces), falling back to the document's ones
+func (tra *SwaggerTransformer) mediaTypesOf(op map[string]interface{}, key string) []string {
+	if mediaTypes := stringSlice(op[key]); len(mediaTypes) > 0 {
+		return mediaTypes
+	}
+
+	if mediaTypes := stringSlice(tra.doc[key]); len(mediaTypes) > 0 {
+		return mediaTypes
+	}
+
+	return []string{defaultSwaggerMediaType}
+}
+
+// resolve Follows the local reference of the element given within the section (prefix) of the document
+func (tra *SwaggerTransformer) resolve(v interface{}, prefix string) interface{} {
+	for i := 0; i < maxRefDepth; i++ {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return v
+		}
+
+		ref, ok := m["$ref"].(string)
+		if !ok || !strings.HasPrefix(ref, prefix) {
+			return v
+		}
+
+		section, _ := tra.doc[strings.Trim(prefix[1:], "/")].(map[string]interface{})
+		v = section[strings.TrimPrefix(ref, prefix)]
+	}
+
+	return v
+}
+
+//generic helper methods, unbound to struct
+
+// parameterSchema Builds the schema of a non body parameter from its type's fields
+func parameterSchema(param map[string]interface{}) map[string]interface{} {
+	schema := make(map[string]interface{})
+
+	for _, key := range schemaFacets {
+		if v, ok := param[key]; ok {
+			schema[key] = rewriteRefs(v)
+		}
+	}
+
+	if schema["type"] == "file" {
+		schema["type"] = "string"
+		schema["format"] = "binary"
+	}
+
+	return schema
+}
+
+// formMediaTypes Returns the form's media types consumed, using the default ones of the form if none was declared
+func formMediaTypes(consumes []string, form map[string]interface{}) (mediaTypes []string) {
+	for _, mediaType := range consumes {
+		if mediaType == "multipart/form-data" || mediaType == "application/x-www-form-urlencoded" {
+			mediaTypes = append(mediaTypes, mediaType)
+		}
+	}
+
+	if len(mediaTypes) > 0 {
+		return
+	}
+
+	if form["file"] == true {
+		return []string{"multipart/form-data"}
+	}
+
+	return []string{"application/x-www-form-urlencoded"}
+}
+
+// rewriteRefs Rewrites the references to swagger's definitions into references to OpenAPI's schemas
+func rewriteRefs(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			if ref, ok := item.(string); ok && k == "$ref" {
+				m[k] = strings.Replace(ref, "#/definitions/", "#/components/schemas/", 1)
+				continue
+			}
+			m[k] = rewriteRefs(item)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(val))
+		for i, item := range val {
+			s[i] = rewriteRefs(item)
+		}
+		return s
+	}
+
+	return v
+}
+
+func hasMediaType(mediaTypes []definition.MediaType, mediaType string) bool {
+	for _, m := range mediaTypes {
+		if string(m) == mediaType {
+			return true
+		}
+	}
+	return false
+}